@@ -66,7 +66,7 @@ func TestStorageSlots(t *testing.T) {
 	state, _ := NewArbosMemoryBackedArbOSState()
 	sto := state.BackingStorage().OpenCachedSubStorage([]byte{})
 
-	println("nil address", colors.Blue, storage.NilAddressRepresentation.String(), colors.Clear)
+	println("nil address", colors.Blue.Wrap(storage.NilAddressRepresentation.String()))
 
 	a := sto.GetStorageSlot(util.IntToHash(0))
 	b := sto.GetStorageSlot(util.IntToHash(1))
@@ -84,10 +84,10 @@ func TestStorageSlots(t *testing.T) {
 	}
 
 	if a[31] != 0 || b[31] != 1 || c[31] != 255 || d[31] != 0 {
-		println("offset 0\t", colors.Red, a.String(), colors.Clear)
-		println("offset 1\t", colors.Red, b.String(), colors.Clear)
-		println("offset 255\t", colors.Red, c.String(), colors.Clear)
-		println("offset 256\t", colors.Red, d.String(), colors.Clear)
+		println("offset 0\t", colors.Red.Wrap(a.String()))
+		println("offset 1\t", colors.Red.Wrap(b.String()))
+		println("offset 255\t", colors.Red.Wrap(c.String()))
+		println("offset 256\t", colors.Red.Wrap(d.String()))
 		Fail(t, "page offset mismatch")
 	}
 }
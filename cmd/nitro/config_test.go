@@ -96,9 +96,9 @@ func TestReloads(t *testing.T) {
 			dot := path + "." + node.Type().Field(i).Name
 			if hot && cold {
 				t.Fatalf(
-					"Option %v%v%v is reloadable but %v%v%v is not",
-					colors.Red, dot, colors.Clear,
-					colors.Red, path, colors.Clear,
+					"Option %v is reloadable but %v is not",
+					colors.Red.Wrap(dot),
+					colors.Red.Wrap(path),
 				)
 			}
 			if hot {
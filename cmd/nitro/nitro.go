@@ -784,7 +784,7 @@ func (c *NodeConfig) CanReload(new *NodeConfig) error {
 			other := value.Field(i).Interface()
 
 			if !hot && !reflect.DeepEqual(first, other) {
-				err = fmt.Errorf("illegal change to %v%v%v", colors.Red, dot, colors.Clear)
+				err = fmt.Errorf("illegal change to %v", colors.Red.Wrap(dot))
 			} else {
 				check(node.Field(i), value.Field(i), dot)
 			}
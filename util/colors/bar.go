@@ -0,0 +1,89 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	barWidth = 40 // cells in a redrawn bar
+	barStep  = 10 // percentage points between plain progress lines
+)
+
+// Bar draws the progress of a long-running operation. On a terminal it redraws a single line,
+// elsewhere it prints a plain line every 10 percent so logs aren't flooded.
+type Bar struct {
+	mutex    sync.Mutex
+	total    int
+	writer   io.Writer
+	terminal bool
+	drawn    bool // whether a redrawn line needs ending
+	reported int  // the last percentage printed as a plain line
+}
+
+// NewBar makes a bar that reaches 100 percent once the current count reaches total.
+func NewBar(total int) *Bar {
+	return &Bar{
+		total:    total,
+		writer:   os.Stdout,
+		terminal: terminal,
+		reported: -barStep,
+	}
+}
+
+// SetOutput directs the bar to the writer, which is only redrawn if it's a terminal.
+func (b *Bar) SetOutput(w io.Writer) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.writer = w
+	b.terminal = false
+	if file, ok := w.(*os.File); ok {
+		b.terminal = isTerminal(file)
+	}
+}
+
+// Update shows the progress made so far, clamping current to the range [0, total].
+func (b *Bar) Update(current int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	percent := 100
+	if b.total > 0 {
+		current = min(max(current, 0), b.total)
+		percent = current * 100 / b.total
+	}
+
+	if !b.terminal {
+		if percent >= b.reported+barStep || (percent == 100 && b.reported != 100) {
+			fmt.Fprintf(b.writer, "%d%%\n", percent)
+			b.reported = percent
+		}
+		return
+	}
+
+	filled := percent * barWidth / 100
+	done := strings.Repeat("█", filled)
+	left := strings.Repeat("░", barWidth-filled)
+	if Enabled() {
+		palette := CurrentPalette()
+		done, left = Downsample(palette.Mint).Wrap(done), Downsample(palette.Grey).Wrap(left)
+	}
+	fmt.Fprintf(b.writer, "\r%s%s %3d%%", done, left, percent)
+	b.drawn = true
+}
+
+// Finish ends the line being redrawn so later output starts on a fresh line.
+func (b *Bar) Finish() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.drawn {
+		fmt.Fprintln(b.writer)
+		b.drawn = false
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestBarPlain(t *testing.T) {
+	var buffer bytes.Buffer
+	bar := NewBar(200)
+	bar.SetOutput(&buffer)
+	for i := 0; i <= 210; i += 7 {
+		bar.Update(i)
+	}
+	bar.Update(200)
+	bar.Finish()
+
+	expected := "0%\n10%\n21%\n31%\n42%\n52%\n63%\n73%\n84%\n94%\n100%\n"
+	if buffer.String() != expected {
+		t.Errorf("unexpected progress %q", buffer.String())
+	}
+}
+
+func TestBarEmpty(t *testing.T) {
+	var buffer bytes.Buffer
+	bar := NewBar(0)
+	bar.SetOutput(&buffer)
+	bar.Update(0)
+	bar.Update(5)
+	if buffer.String() != "100%\n" {
+		t.Errorf("unexpected progress %q", buffer.String())
+	}
+}
+
+func TestBarTerminal(t *testing.T) {
+	var buffer bytes.Buffer
+	bar := NewBar(4)
+	bar.writer, bar.terminal = &buffer, true
+
+	bar.Update(1)
+	bar.Update(-1)
+	bar.Finish()
+	bar.Finish()
+
+	quarter := "\r" + Mint.Wrap(strings.Repeat("█", 10)) + Grey.Wrap(strings.Repeat("░", 30)) + "  25%"
+	empty := "\r" + Mint.Wrap("") + Grey.Wrap(strings.Repeat("░", 40)) + "   0%"
+	if buffer.String() != quarter+empty+"\n" {
+		t.Errorf("unexpected bar %q", buffer.String())
+	}
+
+	buffer.Reset()
+	Disable()
+	defer Enable()
+	bar.Update(4)
+	if buffer.String() != "\r"+strings.Repeat("█", 40)+" 100%" {
+		t.Errorf("unexpected plain bar %q", buffer.String())
+	}
+}
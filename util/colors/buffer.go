@@ -0,0 +1,45 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "strings"
+
+// Buffer builds up a message from colored and plain segments, then renders it with or without
+// colors once the destination is known. The zero value is an empty buffer ready to use.
+type Buffer struct {
+	segments []segment
+}
+
+type segment struct {
+	color Color // None for plain text
+	text  string
+}
+
+// Append adds the text in the given color.
+func (b *Buffer) Append(c Color, s string) {
+	b.segments = append(b.segments, segment{c, s})
+}
+
+// AppendPlain adds the text without a color.
+func (b *Buffer) AppendPlain(s string) {
+	b.segments = append(b.segments, segment{None, s})
+}
+
+// String renders the segments with their colors, unless colors are disabled.
+func (b *Buffer) String() string {
+	var builder strings.Builder
+	for _, segment := range b.segments {
+		builder.WriteString(segment.color.Wrap(segment.text))
+	}
+	return builder.String()
+}
+
+// Plain renders the segments without any colors.
+func (b *Buffer) Plain() string {
+	var builder strings.Builder
+	for _, segment := range b.segments {
+		builder.WriteString(segment.text)
+	}
+	return builder.String()
+}
@@ -0,0 +1,33 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "testing"
+
+func TestBuffer(t *testing.T) {
+	var empty Buffer
+	if empty.String() != "" || empty.Plain() != "" {
+		t.Error("empty buffer should render empty")
+	}
+
+	var buffer Buffer
+	buffer.Append(Red, "error")
+	buffer.AppendPlain(": block ")
+	buffer.Append(Style(Bold, Blue), "42")
+	buffer.AppendPlain(" reorged")
+
+	colored := Red.Wrap("error") + ": block " + Style(Bold, Blue).Wrap("42") + " reorged"
+	if buffer.String() != colored {
+		t.Errorf("unexpected colored rendering %q", buffer.String())
+	}
+	if buffer.Plain() != "error: block 42 reorged" || StripColor(buffer.String()) != buffer.Plain() {
+		t.Errorf("unexpected plain rendering %q", buffer.Plain())
+	}
+
+	Disable()
+	defer Enable()
+	if buffer.String() != buffer.Plain() {
+		t.Errorf("disabled colors kept: %q", buffer.String())
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ColorLevel is how many colors a terminal can display. The values match the levels that
+// FORCE_COLOR requests.
+type ColorLevel int
+
+const (
+	LevelNone      ColorLevel = iota // no colors at all
+	LevelBasic                       // the 8 standard colors
+	Level256                         // the 256-color palette
+	LevelTrueColor                   // any 24-bit color
+)
+
+// colorLevel records how many colors the terminal advertises, or FORCE_COLOR requests.
+var colorLevel = initialLevel()
+
+func initialLevel() ColorLevel {
+	if level, ok := forcedLevel(os.LookupEnv("FORCE_COLOR")); ok {
+		return level
+	}
+	return detectLevel(os.Getenv("COLORTERM"), os.Getenv("TERM"), runtime.GOOS)
+}
+
+// forcedLevel parses a FORCE_COLOR value the way the npm ecosystem does: 0 through 3 pick a
+// level, with larger numbers meaning truecolor, while an empty value or "true" means the
+// standard colors and "false" means none. It reports false if the variable isn't set or
+// can't be parsed, leaving the level to the other heuristics.
+func forcedLevel(value string, set bool) (ColorLevel, bool) {
+	if !set {
+		return LevelNone, false
+	}
+	switch value {
+	case "", "true":
+		return LevelBasic, true
+	case "false":
+		return LevelNone, true
+	}
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 {
+		return LevelNone, false
+	}
+	return min(ColorLevel(level), LevelTrueColor), true
+}
+
+// Level reports how many colors the terminal supports, taking FORCE_COLOR into account.
+// Extended colors are downsampled to this level before they're printed.
+func Level() ColorLevel {
+	return colorLevel
+}
+
+// detectLevel infers how many colors a terminal supports from COLORTERM and TERM. Dumb
+// terminals get none, as do those without a TERM outside of Windows, where TERM usually isn't
+// set. Only terminals known to be limited to the standard colors get LevelBasic, while the
+// rest are assumed to support the 256-color palette the package has always used.
+func detectLevel(colorterm, terminal, goos string) ColorLevel {
+	switch {
+	case terminal == "dumb" || (terminal == "" && goos != "windows"):
+		return LevelNone
+	case colorterm == "truecolor" || colorterm == "24bit":
+		return LevelTrueColor
+	case strings.HasSuffix(terminal, "-direct") || strings.HasSuffix(terminal, "-truecolor"):
+		return LevelTrueColor
+	case basicTerminals[terminal]:
+		return LevelBasic
+	default:
+		return Level256
+	}
+}
+
+// basicTerminals names the terminals known to display only the standard colors.
+var basicTerminals = map[string]bool{
+	"linux": true,
+	"vt100": true,
+	"vt102": true,
+	"vt220": true,
+	"ansi":  true,
+}
+
+// Downsample replaces the 24-bit and 256-color parts of the color with the closest colors the
+// terminal can display, leaving the color as is when the terminal supports it. At LevelNone,
+// where colors are disabled anyway, the standard colors are used should they be enabled.
+func Downsample(c Color) Color {
+	return downsample(c, colorLevel)
+}
+
+// Best gives the richest form of the color the current level supports: the color as is for
+// truecolor terminals, and the closest colors of the 256-color palette or the standard colors
+// for terminals that support only those. Like Downsample, it gives the standard colors at
+// LevelNone, since colors are only enabled there when they're forced, so it agrees with what
+// the print helpers emit.
+func Best(c Color) Color {
+	return downsample(c, Level())
+}
+
+func downsample(c Color, to ColorLevel) Color {
+	if to >= LevelTrueColor {
+		return c
+	}
+	params := sgrParams(string(c))
+	changed := false
+	for i, param := range params {
+		if lowered := downsampleParam(param, to); lowered != param {
+			params[i], changed = lowered, true
+		}
+	}
+	if !changed {
+		return c
+	}
+	return sgr(params...)
+}
+
+// downsampleParam lowers an SGR parameter setting an extended color to the given depth.
+func downsampleParam(param string, to ColorLevel) string {
+	parts := strings.Split(param, ";")
+	if len(parts) < 3 || (parts[0] != "38" && parts[0] != "48") {
+		return param
+	}
+	extendedTrue := len(parts) == 5 && parts[1] == "2"
+	if !extendedTrue && to >= Level256 {
+		return param
+	}
+	r, g, b, ok := paramRGB(param)
+	if !ok {
+		return param
+	}
+	if to >= Level256 {
+		return fmt.Sprintf("%s;5;%d", parts[0], nearest256(r, g, b))
+	}
+	base := 30
+	if parts[0] == "48" {
+		base = 40
+	}
+	return fmt.Sprint(base + nearestBasic(r, g, b))
+}
@@ -0,0 +1,160 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"testing"
+)
+
+// withLevel pretends the terminal supports the given number of colors for the duration of the test.
+func withLevel(t *testing.T, supported ColorLevel) {
+	t.Helper()
+	prior := colorLevel
+	colorLevel = supported
+	changed()
+	t.Cleanup(func() {
+		colorLevel = prior
+		changed()
+	})
+}
+
+func TestDetectLevel(t *testing.T) {
+	cases := []struct {
+		colorterm, terminal, goos string
+		level                     ColorLevel
+	}{
+		{"truecolor", "xterm-256color", "linux", LevelTrueColor},
+		{"24bit", "xterm", "linux", LevelTrueColor},
+		{"", "xterm-direct", "linux", LevelTrueColor},
+		{"", "xterm-256color", "linux", Level256},
+		{"", "screen-256color", "linux", Level256},
+		{"", "xterm", "linux", Level256},
+		{"", "screen", "linux", Level256},
+		{"", "tmux", "darwin", Level256},
+		{"", "", "windows", Level256},
+		{"", "linux", "linux", LevelBasic},
+		{"", "vt100", "linux", LevelBasic},
+		{"", "ansi", "linux", LevelBasic},
+		{"", "dumb", "linux", LevelNone},
+		{"truecolor", "dumb", "linux", LevelNone},
+		{"", "dumb", "windows", LevelNone},
+		{"", "", "linux", LevelNone},
+	}
+	for _, test := range cases {
+		if detected := detectLevel(test.colorterm, test.terminal, test.goos); detected != test.level {
+			t.Errorf("COLORTERM=%q TERM=%q on %v detected level %v, expected %v", test.colorterm, test.terminal, test.goos, detected, test.level)
+		}
+	}
+}
+
+func TestForcedLevel(t *testing.T) {
+	cases := []struct {
+		value string
+		set   bool
+		level ColorLevel
+		ok    bool
+	}{
+		{"0", true, LevelNone, true},
+		{"1", true, LevelBasic, true},
+		{"2", true, Level256, true},
+		{"3", true, LevelTrueColor, true},
+		{"4", true, LevelTrueColor, true},
+		{"", true, LevelBasic, true},
+		{"true", true, LevelBasic, true},
+		{"false", true, LevelNone, true},
+		{"-1", true, LevelNone, false},
+		{"always", true, LevelNone, false},
+		{"", false, LevelNone, false},
+	}
+	for _, test := range cases {
+		level, ok := forcedLevel(test.value, test.set)
+		if level != test.level || ok != test.ok {
+			t.Errorf("FORCE_COLOR=%q (set %v) gave level %v %v, expected %v %v", test.value, test.set, level, ok, test.level, test.ok)
+		}
+	}
+
+	withLevel(t, LevelBasic)
+	if Level() != LevelBasic {
+		t.Errorf("unexpected level %v", Level())
+	}
+}
+
+func TestDownsample(t *testing.T) {
+	basic := []struct {
+		color   Color
+		nearest Color
+	}{
+		{"\033[38;5;196m", "\033[31m"},
+		{"\033[38;5;21m", "\033[34m"},
+		{"\033[38;5;226m", "\033[33m"},
+		{"\033[38;5;231m", "\033[37m"},
+		{"\033[38;5;16m", "\033[30m"},
+		{"\033[38;5;51m", "\033[36m"},
+		{"\033[38;5;48;1m", "\033[36;1m"},
+		{"\033[48;5;124m", "\033[41m"},
+		{"\033[38;2;200;0;210m", "\033[35m"},
+		{"\033[31;1m", "\033[31;1m"},
+		{"", ""},
+	}
+	for _, test := range basic {
+		if nearest := downsample(test.color, LevelBasic); nearest != test.nearest {
+			t.Errorf("downsampled %q to %q, expected %q", test.color, nearest, test.nearest)
+		}
+	}
+
+	if nearest := downsample("\033[38;2;255;170;0m", Level256); nearest != "\033[38;5;214m" {
+		t.Errorf("downsampled truecolor to %q", nearest)
+	}
+	if same := downsample("\033[38;5;161;1m", Level256); same != "\033[38;5;161;1m" {
+		t.Errorf("256 colors must survive a 256-color terminal, got %q", same)
+	}
+	if same := downsample("\033[38;2;1;2;3m", LevelTrueColor); same != "\033[38;2;1;2;3m" {
+		t.Errorf("truecolor must survive a truecolor terminal, got %q", same)
+	}
+
+	withLevel(t, LevelBasic)
+	var buffer bytes.Buffer
+	if _, err := Fprint(&buffer, Mint, "mint"); err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != "\033[36;1mmint"+string(Clear) {
+		t.Errorf("Fprint must downsample, got %q", buffer.String())
+	}
+}
+
+func TestBest(t *testing.T) {
+	brand := Color("\033[38;2;40;160;240m")
+	cases := []struct {
+		level ColorLevel
+		brand Color
+		pink  Color
+	}{
+		{LevelTrueColor, brand, Pink},
+		{Level256, "\033[38;5;39m", Pink},
+		{LevelBasic, "\033[36m", "\033[31;1m"},
+		{LevelNone, "\033[36m", "\033[31;1m"},
+	}
+	for _, test := range cases {
+		withLevel(t, test.level)
+		if best := Best(brand); best != test.brand {
+			t.Errorf("at level %v the best form of %q is %q, expected %q", test.level, brand, best, test.brand)
+		}
+		if best := Best(Pink); best != test.pink {
+			t.Errorf("at level %v the best form of %q is %q, expected %q", test.level, Pink, best, test.pink)
+		}
+	}
+
+	// colors forced on a dumb terminal
+	withLevel(t, LevelNone)
+	ForceColor(true)
+	defer ForceColor(false)
+	var printed bytes.Buffer
+	if _, err := Fprint(&printed, brand, "x"); err != nil {
+		t.Fatal(err)
+	}
+	if best := Best(brand).Wrap("x"); best != printed.String() {
+		t.Errorf("forced at LevelNone, Best gave %q while Fprint printed %q", best, printed.String())
+	}
+}
@@ -5,63 +5,540 @@ package colors
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"regexp"
+	"runtime"
+	"sync/atomic"
+
+	"golang.org/x/term"
 )
 
-var Red = "\033[31;1m"
-var Blue = "\033[34;1m"
-var Yellow = "\033[33;1m"
-var Pink = "\033[38;5;161;1m"
-var Mint = "\033[38;5;48;1m"
-var Grey = "\033[90m"
+// Color is an ANSI escape sequence that sets the style of subsequent text.
+type Color string
+
+// None is the absence of a color. Text wrapped or printed in it is left exactly as is, without
+// a reset at the end.
+const None Color = ""
+
+// Wrap colors the string, resetting the style afterward. The string is left as is when
+// colors are disabled.
+func (c Color) Wrap(s string) string {
+	if !Enabled() {
+		return s
+	}
+	return c.wrap(s)
+}
+
+// WrapFg colors the string like Wrap, but afterward resets only the foreground color, so a
+// background set around it carries on. Styles the color includes, like the bold of Red, stay
+// on too; Reset gives the sequence turning off everything a color sets.
+func (c Color) WrapFg(s string) string {
+	if !Enabled() || c == None {
+		return s
+	}
+	return string(c) + s + string(ClearFg)
+}
+
+// WrapBg colors the string like Wrap, but afterward resets only the background color, so a
+// foreground set around it carries on.
+func (c Color) WrapBg(s string) string {
+	if !Enabled() || c == None {
+		return s
+	}
+	return string(c) + s + string(ClearBg)
+}
+
+// wrap colors the string whether or not colors are enabled.
+func (c Color) wrap(s string) string {
+	if c == None {
+		return s
+	}
+	return string(c) + s + string(CurrentPalette().Clear)
+}
+
+// Sprint formats the args like fmt.Sprint and colors the result.
+func (c Color) Sprint(args ...interface{}) string {
+	return c.Wrap(fmt.Sprint(args...))
+}
+
+// Sprintf formats the args like fmt.Sprintf and colors the result.
+func (c Color) Sprintf(format string, args ...interface{}) string {
+	return c.Wrap(fmt.Sprintf(format, args...))
+}
+
+// Sprintln formats the args like fmt.Sprintln and colors the result, placing the reset before
+// the trailing newline so the color doesn't carry over to the next line. Unlike Sprint, the
+// args are always separated by spaces.
+func (c Color) Sprintln(args ...interface{}) string {
+	line := fmt.Sprintln(args...)
+	return c.Wrap(line[:len(line)-1]) + "\n"
+}
+
+// The exported colors are meant to be read, not assigned, since assignments race with the
+// helpers. Use SetPalette to change the colors of helpers like PrintRed, or Disable to stop
+// emitting colors altogether.
+
+var Red Color = "\033[31;1m"
+var Blue Color = "\033[34;1m"
+var Yellow Color = "\033[33;1m"
+var Pink Color = "\033[38;5;161;1m"
+var Mint Color = "\033[38;5;48;1m"
+var Grey Color = "\033[90m"
+
+var Lime Color = "\033[38;5;119;1m"
+var Lavender Color = "\033[38;5;183;1m"
+var Maroon Color = "\033[38;5;124;1m"
+var Orange Color = "\033[38;5;202;1m"
+
+var Black Color = "\033[30m"
+var Green Color = "\033[32m"
+var Magenta Color = "\033[35m"
+var Cyan Color = "\033[36m"
+var White Color = "\033[37m"
+
+var BrightBlack Color = "\033[90m"
+var BrightRed Color = "\033[91m"
+var BrightGreen Color = "\033[92m"
+var BrightYellow Color = "\033[93m"
+var BrightBlue Color = "\033[94m"
+var BrightMagenta Color = "\033[95m"
+var BrightCyan Color = "\033[96m"
+var BrightWhite Color = "\033[97m"
+
+var BgRed Color = "\033[41m"
+var BgBlue Color = "\033[44m"
+var BgYellow Color = "\033[43m"
+var BgPink Color = "\033[48;5;161m"
+var BgMint Color = "\033[48;5;48m"
+var BgGrey Color = "\033[100m"
+
+var BgLime Color = "\033[48;5;119m"
+var BgLavender Color = "\033[48;5;183m"
+var BgMaroon Color = "\033[48;5;124m"
+var BgOrange Color = "\033[48;5;202m"
+
+var Clear Color = "\033[0;0m"
+
+// ClearFg and ClearBg reset only the foreground or the background color.
+var ClearFg Color = "\033[39m"
+var ClearBg Color = "\033[49m"
+
+// Palette holds the colors used by the helpers named after them, like PrintRed and SprintMint.
+type Palette struct {
+	Red, Blue, Yellow, Pink, Mint, Grey Color
+	Lime, Lavender, Maroon, Orange      Color
+	Clear                               Color
+}
+
+var defaultPalette = Palette{
+	Red: Red, Blue: Blue, Yellow: Yellow, Pink: Pink, Mint: Mint, Grey: Grey,
+	Lime: Lime, Lavender: Lavender, Maroon: Maroon, Orange: Orange,
+	Clear: Clear,
+}
+
+var palette atomic.Pointer[Palette]
+
+func init() {
+	palette.Store(&defaultPalette)
+}
+
+// DefaultPalette returns the palette the helpers start out with.
+func DefaultPalette() Palette {
+	return defaultPalette
+}
+
+// CurrentPalette returns the palette the helpers are using.
+func CurrentPalette() Palette {
+	return *palette.Load()
+}
+
+// SetPalette atomically replaces the colors the helpers use, so it's safe to call while
+// other goroutines print. A palette without a Clear uses the package's, so colored text is
+// always reset.
+func SetPalette(p Palette) {
+	if p.Clear == "" {
+		p.Clear = Clear
+	}
+	palette.Store(&p)
+	changed()
+}
+
+// forceColor makes the print helpers emit colors even when stdout isn't a terminal.
+var forceColor atomic.Bool
+
+// enabled gates the colors the helpers emit.
+var enabled atomic.Bool
 
-var Lime = "\033[38;5;119;1m"
-var Lavender = "\033[38;5;183;1m"
-var Maroon = "\033[38;5;124;1m"
-var Orange = "\033[38;5;202;1m"
+func init() {
+	force, allowed := environmentColors(os.LookupEnv, runtime.GOOS)
+	forceColor.Store(force)
+	enabled.Store(allowed && enableVirtualTerminal())
+}
+
+// environmentColors decides from the environment whether colors are forced even when stdout
+// isn't a terminal, and whether they're allowed at all.
+func environmentColors(lookup func(string) (string, bool), goos string) (force, allowed bool) {
+	getenv := func(key string) string {
+		value, _ := lookup(key)
+		return value
+	}
+
+	// CLICOLOR_FORCE requests colors no matter what else the environment says, including
+	// when stdout isn't a terminal. See https://bixense.com/clicolors for the convention.
+	force = getenv("CLICOLOR_FORCE") != "" && getenv("CLICOLOR_FORCE") != "0"
+
+	// FORCE_COLOR does the same while also picking a color level, except that level 0
+	// disables colors.
+	forced, ok := forcedLevel(lookup("FORCE_COLOR"))
+	if ok && forced == LevelNone {
+		return false, false
+	}
+	force = force || ok
+
+	// Any non-empty NO_COLOR disables colors (https://no-color.org), as does CLICOLOR=0.
+	allowed = getenv("NO_COLOR") == "" && (force || getenv("CLICOLOR") != "0")
+
+	// Dumb terminals, like those of many CI systems, can't interpret escape sequences at all.
+	if detectLevel(getenv("COLORTERM"), getenv("TERM"), goos) == LevelNone {
+		allowed = allowed && force
+	}
+	return force, allowed
+}
+
+// Enabled reports whether the helpers emit colors. Colors start out enabled unless the
+// environment sets NO_COLOR, CLICOLOR=0, or FORCE_COLOR=0, or TERM names a dumb terminal and
+// colors aren't forced.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// SetEnabled turns colors on or off for all the helpers. The exported colors keep their values.
+func SetEnabled(enable bool) {
+	if enabled.Swap(enable) != enable {
+		changed()
+	}
+}
+
+// Enable turns on colors for all the helpers, regardless of the environment.
+func Enable() {
+	SetEnabled(true)
+}
+
+// Disable makes all the helpers emit plain text.
+func Disable() {
+	SetEnabled(false)
+}
+
+// terminal records whether stdout was a terminal at startup.
+var terminal = isTerminal(os.Stdout)
+
+func isTerminal(file *os.File) bool {
+	return term.IsTerminal(int(file.Fd()))
+}
+
+// IsTerminal reports whether stdout is a terminal, in which case the print helpers emit colors.
+func IsTerminal() bool {
+	return terminal
+}
 
-var Clear = "\033[0;0m"
+// defaultTerminalWidth is assumed when the terminal's width can't be determined.
+const defaultTerminalWidth = 80
+
+// TerminalWidth gives the number of columns of the terminal attached to stdout, or 80 if stdout
+// isn't a terminal or its size is unknown.
+func TerminalWidth() int {
+	return terminalWidth(os.Stdout)
+}
+
+func terminalWidth(file *os.File) int {
+	width, _, err := term.GetSize(int(file.Fd()))
+	if err != nil || width <= 0 {
+		return defaultTerminalWidth
+	}
+	return width
+}
+
+// ForceColor makes the print helpers emit colors even when stdout isn't a terminal.
+// Colors disabled through Disable, NO_COLOR, or CLICOLOR stay disabled.
+func ForceColor(force bool) {
+	if forceColor.Swap(force) != force {
+		changed()
+	}
+}
+
+// colorStdout reports whether text bound for stdout should carry escape sequences.
+func colorStdout() bool {
+	return Enabled() && (terminal || forceColor.Load())
+}
+
+// stderrTerminal records whether stderr was a terminal at startup. It's tracked separately
+// from stdout since either may be redirected without the other.
+var stderrTerminal = isTerminal(os.Stderr)
+
+// colorStderr reports whether text bound for stderr should carry escape sequences.
+func colorStderr() bool {
+	return Enabled() && (stderrTerminal || forceColor.Load())
+}
+
+// printColor writes the args to stdout in the given color, falling back to plain text
+// when stdout isn't a terminal.
+func printColor(color Color, args ...interface{}) {
+	fprintColor(os.Stdout, colorStdout(), color, args...)
+}
+
+// fprintColor writes the args to the file followed by a newline, in the given color if colored.
+func fprintColor(file *os.File, colored bool, color Color, args ...interface{}) {
+	if colored {
+		Fprint(file, color, args...)
+	} else {
+		fmt.Fprint(file, args...)
+	}
+	fmt.Fprintln(file)
+}
 
 func PrintBlue(args ...interface{}) {
-	print(Blue)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Blue, args...)
 }
 
 func PrintGrey(args ...interface{}) {
-	print(Grey)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Grey, args...)
 }
 
 func PrintMint(args ...interface{}) {
-	print(Mint)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Mint, args...)
 }
 
 func PrintRed(args ...interface{}) {
-	print(Red)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Red, args...)
 }
 
 func PrintYellow(args ...interface{}) {
-	print(Yellow)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Yellow, args...)
 }
 
 func PrintPink(args ...interface{}) {
-	print(Pink)
-	fmt.Print(args...)
-	println(Clear)
+	printColor(CurrentPalette().Pink, args...)
 }
 
+func PrintLime(args ...interface{}) {
+	printColor(CurrentPalette().Lime, args...)
+}
+
+func PrintLavender(args ...interface{}) {
+	printColor(CurrentPalette().Lavender, args...)
+}
+
+func PrintMaroon(args ...interface{}) {
+	printColor(CurrentPalette().Maroon, args...)
+}
+
+func PrintOrange(args ...interface{}) {
+	printColor(CurrentPalette().Orange, args...)
+}
+
+// Eprint writes the args to stderr in the given color followed by a newline, like the stdout
+// helpers such as PrintRed. Colors are skipped when stderr isn't a terminal.
+func Eprint(color Color, args ...interface{}) {
+	fprintColor(os.Stderr, colorStderr(), color, args...)
+}
+
+func EprintBlue(args ...interface{}) {
+	Eprint(CurrentPalette().Blue, args...)
+}
+
+func EprintGrey(args ...interface{}) {
+	Eprint(CurrentPalette().Grey, args...)
+}
+
+func EprintMint(args ...interface{}) {
+	Eprint(CurrentPalette().Mint, args...)
+}
+
+func EprintRed(args ...interface{}) {
+	Eprint(CurrentPalette().Red, args...)
+}
+
+func EprintYellow(args ...interface{}) {
+	Eprint(CurrentPalette().Yellow, args...)
+}
+
+func EprintPink(args ...interface{}) {
+	Eprint(CurrentPalette().Pink, args...)
+}
+
+func EprintLime(args ...interface{}) {
+	Eprint(CurrentPalette().Lime, args...)
+}
+
+func EprintLavender(args ...interface{}) {
+	Eprint(CurrentPalette().Lavender, args...)
+}
+
+func EprintMaroon(args ...interface{}) {
+	Eprint(CurrentPalette().Maroon, args...)
+}
+
+func EprintOrange(args ...interface{}) {
+	Eprint(CurrentPalette().Orange, args...)
+}
+
+// Sprint formats the args like fmt.Sprint in the given color, returning the result
+// rather than printing it.
+func Sprint(color Color, args ...interface{}) string {
+	return color.Sprint(args...)
+}
+
+func SprintBlue(args ...interface{}) string {
+	return Sprint(CurrentPalette().Blue, args...)
+}
+
+func SprintGrey(args ...interface{}) string {
+	return Sprint(CurrentPalette().Grey, args...)
+}
+
+func SprintMint(args ...interface{}) string {
+	return Sprint(CurrentPalette().Mint, args...)
+}
+
+func SprintRed(args ...interface{}) string {
+	return Sprint(CurrentPalette().Red, args...)
+}
+
+func SprintYellow(args ...interface{}) string {
+	return Sprint(CurrentPalette().Yellow, args...)
+}
+
+func SprintPink(args ...interface{}) string {
+	return Sprint(CurrentPalette().Pink, args...)
+}
+
+func SprintLime(args ...interface{}) string {
+	return Sprint(CurrentPalette().Lime, args...)
+}
+
+func SprintLavender(args ...interface{}) string {
+	return Sprint(CurrentPalette().Lavender, args...)
+}
+
+func SprintMaroon(args ...interface{}) string {
+	return Sprint(CurrentPalette().Maroon, args...)
+}
+
+func SprintOrange(args ...interface{}) string {
+	return Sprint(CurrentPalette().Orange, args...)
+}
+
+// uncolor matches CSI sequences, which include colors and cursor movements, as well as OSC
+// strings like hyperlinks, which end in either BEL or ST.
+var uncolor = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\))`)
+var unwhite = regexp.MustCompile(`\s+`)
+
+// Fprint formats the args like fmt.Fprint in the given color, writing them to w. The color is
+// downsampled to what the terminal supports.
+func Fprint(w io.Writer, color Color, args ...interface{}) (int, error) {
+	return io.WriteString(w, Downsample(color).Sprint(args...))
+}
+
+func FprintBlue(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Blue, args...)
+}
+
+func FprintGrey(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Grey, args...)
+}
+
+func FprintMint(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Mint, args...)
+}
+
+func FprintRed(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Red, args...)
+}
+
+func FprintYellow(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Yellow, args...)
+}
+
+func FprintPink(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Pink, args...)
+}
+
+func FprintLime(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Lime, args...)
+}
+
+func FprintLavender(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Lavender, args...)
+}
+
+func FprintMaroon(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Maroon, args...)
+}
+
+func FprintOrange(w io.Writer, args ...interface{}) (int, error) {
+	return Fprint(w, CurrentPalette().Orange, args...)
+}
+
+// Sprintln formats the args like fmt.Sprintln in the given color, ending with the reset
+// followed by a newline. The per-color print helpers like PrintRed order the reset and newline
+// the same way but space their args like fmt.Print.
+func Sprintln(color Color, args ...interface{}) string {
+	return color.Sprintln(args...)
+}
+
+// PrintlnColor writes the args to stdout like fmt.Println in the given color, falling back to
+// plain text when stdout isn't a terminal.
+func PrintlnColor(color Color, args ...interface{}) {
+	if !colorStdout() {
+		fmt.Fprintln(os.Stdout, args...)
+		return
+	}
+	fmt.Fprint(os.Stdout, Sprintln(Downsample(color), args...))
+}
+
+// StripColor removes escape sequences, including colors, cursor movements, and OSC strings
+// like hyperlinks, leaving whitespace untouched.
+func StripColor(text string) string {
+	return uncolor.ReplaceAllString(text, "")
+}
+
+// Adapt returns the text as is when stdout shows colors, meaning they're enabled and stdout is
+// a terminal or colors are forced, and strips its escape sequences otherwise.
+func Adapt(text string) string {
+	if colorStdout() {
+		return text
+	}
+	return StripColor(text)
+}
+
+// Uncolor removes escape sequences like StripColor, then collapses each run of whitespace
+// into a single space.
 func Uncolor(text string) string {
-	uncolor := regexp.MustCompile("\x1b\\[([0-9]+;)*[0-9]+m")
-	unwhite := regexp.MustCompile(`\s+`)
+	return unwhite.ReplaceAllString(StripColor(text), " ")
+}
 
-	text = uncolor.ReplaceAllString(text, "")
-	return unwhite.ReplaceAllString(text, " ")
+// UncolorBytes is Uncolor for byte slices, sparing callers the conversions to and from strings.
+// It works in place without allocating, so the text is overwritten by the result it returns.
+func UncolorBytes(text []byte) []byte {
+	uncolored := text[:0]
+	space := false // whether the last byte kept was the space for a run of whitespace
+	for i := 0; i < len(text); {
+		if length, _ := escapeLength(text[i:]); length > 0 {
+			i += length
+			continue
+		}
+		switch text[i] {
+		case ' ', '\t', '\n', '\f', '\r': // what \s matches for Uncolor
+			if !space {
+				uncolored = append(uncolored, ' ')
+			}
+			space = true
+		default:
+			uncolored = append(uncolored, text[i])
+			space = false
+		}
+		i++
+	}
+	return uncolored
 }
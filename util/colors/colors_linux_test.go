@@ -0,0 +1,55 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPTY allocates a pseudo-terminal, skipping the test if the system doesn't provide one.
+func openPTY(t *testing.T) (master, slave *os.File) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skip("pseudo-terminals unavailable:", err)
+	}
+	t.Cleanup(func() { master.Close() })
+
+	fd := int(master.Fd())
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		t.Skip("failed to unlock pseudo-terminal:", err)
+	}
+	index, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+	if err != nil {
+		t.Skip("failed to name pseudo-terminal:", err)
+	}
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", index), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skip("failed to open pseudo-terminal:", err)
+	}
+	t.Cleanup(func() { slave.Close() })
+	return master, slave
+}
+
+func TestPTYIsTerminal(t *testing.T) {
+	_, slave := openPTY(t)
+	if !isTerminal(slave) {
+		t.Fatal("pseudo-terminal not detected as a terminal")
+	}
+}
+
+func TestPTYWidth(t *testing.T) {
+	_, slave := openPTY(t)
+	if err := unix.IoctlSetWinsize(int(slave.Fd()), unix.TIOCSWINSZ, &unix.Winsize{Row: 40, Col: 132}); err != nil {
+		t.Skip("failed to resize pseudo-terminal:", err)
+	}
+	if width := terminalWidth(slave); width != 132 {
+		t.Fatalf("expected the pseudo-terminal's width, got %v", width)
+	}
+}
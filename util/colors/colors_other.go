@@ -0,0 +1,11 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+//go:build !windows
+
+package colors
+
+// enableVirtualTerminal is a no-op, since other platforms' terminals understand ANSI escapes.
+func enableVirtualTerminal() bool {
+	return true
+}
@@ -0,0 +1,479 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// The environment's NO_COLOR, CLICOLOR, CLICOLOR_FORCE, FORCE_COLOR, and TERM mustn't change the results.
+	Enable()
+	ForceColor(false)
+	colorLevel = Level256
+	os.Exit(m.Run())
+}
+
+// captureStdout runs the function with stdout redirected to a pipe and returns what was written.
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, f)
+}
+
+// captureStderr runs the function with stderr redirected to a pipe and returns what was written.
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, f)
+}
+
+func captureFile(t *testing.T, file **os.File, f func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prior := *file
+	*file = writer
+	defer func() { *file = prior }()
+
+	f()
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output)
+}
+
+// withTerminal pretends stdout is or isn't a terminal for the duration of the test.
+func withTerminal(t *testing.T, isTerminal bool) {
+	t.Helper()
+	prior := terminal
+	terminal = isTerminal
+	t.Cleanup(func() { terminal = prior })
+}
+
+// withStderrTerminal pretends stderr is or isn't a terminal for the duration of the test.
+func withStderrTerminal(t *testing.T, isTerminal bool) {
+	t.Helper()
+	prior := stderrTerminal
+	stderrTerminal = isTerminal
+	t.Cleanup(func() { stderrTerminal = prior })
+}
+
+func TestPipeIsNotTerminal(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+	if isTerminal(reader) || isTerminal(writer) {
+		t.Fatal("pipe detected as a terminal")
+	}
+}
+
+func TestPrintToPipe(t *testing.T) {
+	withTerminal(t, false)
+	ForceColor(false)
+
+	output := captureStdout(t, func() { PrintRed("plain ", 1) })
+	if output != "plain 1\n" {
+		t.Fatalf("expected plain text when piped, got %q", output)
+	}
+
+	ForceColor(true)
+	defer ForceColor(false)
+	output = captureStdout(t, func() { PrintRed("forced ", 2) })
+	if output != Red.Wrap("forced 2")+"\n" {
+		t.Fatalf("expected colors when forced, got %q", output)
+	}
+}
+
+func TestPrintToTerminal(t *testing.T) {
+	withTerminal(t, true)
+
+	output := captureStdout(t, func() { PrintMint("colored") })
+	if output != Mint.Wrap("colored")+"\n" {
+		t.Fatalf("expected colors on a terminal, got %q", output)
+	}
+}
+
+func TestColorWrap(t *testing.T) {
+	if Red.Wrap("text") != string(Red)+"text"+string(Clear) {
+		t.Fatal("Wrap must surround the text with the color and Clear")
+	}
+	if Blue.Sprint("n=", 7) != Blue.Wrap("n=7") {
+		t.Fatal("Sprint must format like fmt.Sprint")
+	}
+	if Mint.Sprintf("%03d", 7) != Mint.Wrap("007") {
+		t.Fatal("Sprintf must format like fmt.Sprintf")
+	}
+}
+
+func TestSprint(t *testing.T) {
+	if SprintRed("a", 1, 2, "b") != string(Red)+"a1 2b"+string(Clear) {
+		t.Fatal("SprintRed must follow fmt.Sprint spacing")
+	}
+	if Sprint(Pink, "x") != SprintPink("x") {
+		t.Fatal("Sprint and SprintPink disagree")
+	}
+}
+
+func TestUncolor(t *testing.T) {
+	link := "\x1b]8;;https://arbiscan.io\x1b\\explorer\x1b]8;;\x07"
+	cursor := "\x1b[2;10H\x1b[K"
+	text := Red.Wrap("block") + " " + link + cursor + " " + "\x1b[38;5;48;1mmint\x1b[0;0m"
+	if uncolored := Uncolor(text); uncolored != "block explorer mint" {
+		t.Fatalf("unexpected uncolored text %q", uncolored)
+	}
+}
+
+func TestStripColor(t *testing.T) {
+	text := Red.Wrap("first") + "\n\t" + Blue.Wrap("second  line") + "\n"
+	if stripped := StripColor(text); stripped != "first\n\tsecond  line\n" {
+		t.Fatalf("StripColor must preserve whitespace, got %q", stripped)
+	}
+	if uncolored := Uncolor(text); uncolored != "first second line " {
+		t.Fatalf("Uncolor must collapse whitespace, got %q", uncolored)
+	}
+}
+
+func TestUncolorBytes(t *testing.T) {
+	text := Red.Wrap("first") + "\n\t" + Blue.Wrap("second  line") + "\x1b]8;;url\x07!\x1b]8;;\x07"
+	for _, text := range []string{text, "a \x1b[0m\v b\x1b[3", "\x1b]8;;\x1bx  \x1b"} {
+		if uncolored := UncolorBytes([]byte(text)); string(uncolored) != Uncolor(text) {
+			t.Errorf("UncolorBytes disagrees with Uncolor: %q", uncolored)
+		}
+	}
+
+	line := []byte(benchmarkLine)
+	allocs := testing.AllocsPerRun(100, func() {
+		UncolorBytes(append(line[:0], benchmarkLine...))
+	})
+	if allocs != 0 {
+		t.Errorf("UncolorBytes made %v allocations", allocs)
+	}
+}
+
+const benchmarkLine = "INFO \033[38;5;48;1msequencer\033[0;0m posted batch \033[31;1m0xabcdef\033[0;0m  count=12\n"
+
+func BenchmarkUncolor(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Uncolor(benchmarkLine)
+	}
+}
+
+func BenchmarkUncolorBytes(b *testing.B) {
+	line := []byte(benchmarkLine)
+	b.Run("bytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			UncolorBytes(append(line[:0], benchmarkLine...))
+		}
+	})
+	b.Run("string", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = []byte(Uncolor(string(line)))
+		}
+	})
+}
+
+func TestFprint(t *testing.T) {
+	var buffer bytes.Buffer
+	n, err := FprintYellow(&buffer, "warn ", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buffer.String() != Yellow.Wrap("warn 3") || n != buffer.Len() {
+		t.Fatalf("unexpected output %q of length %v", buffer.String(), n)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	withTerminal(t, true)
+	defer Enable()
+
+	Disable()
+	if Enabled() {
+		t.Fatal("colors still enabled")
+	}
+	if text := SprintRed("plain"); text != "plain" {
+		t.Fatalf("expected plain text while disabled, got %q", text)
+	}
+	if output := captureStdout(t, func() { PrintBlue("plain") }); output != "plain\n" {
+		t.Fatalf("expected plain output while disabled, got %q", output)
+	}
+	if Red != "\033[31;1m" {
+		t.Fatal("disabling colors must leave the exported colors alone")
+	}
+
+	SetEnabled(true)
+	if text := SprintRed("red"); text != "\033[31;1mred\033[0;0m" {
+		t.Fatalf("expected colors once enabled, got %q", text)
+	}
+}
+
+func TestSetPalette(t *testing.T) {
+	defer SetPalette(DefaultPalette())
+
+	SetPalette(Palette{Red: "<red>", Clear: "</red>"})
+	if text := SprintRed("alert"); text != "<red>alert</red>" {
+		t.Fatalf("helpers ignored the palette: %q", text)
+	}
+	if Red != DefaultPalette().Red {
+		t.Fatal("setting the palette must leave the exported colors alone")
+	}
+}
+
+func TestConcurrentToggle(t *testing.T) {
+	defer Enable()
+	defer SetPalette(DefaultPalette())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			SetEnabled(i%2 == 0)
+			if i%3 == 0 {
+				SetPalette(Palette{})
+			} else {
+				SetPalette(DefaultPalette())
+			}
+		}
+	}()
+	for printing := true; printing; {
+		select {
+		case <-done:
+			printing = false
+		default:
+		}
+		FprintRed(io.Discard, "racing")
+		SprintMint("racing")
+		Blue.Wrap("racing")
+	}
+}
+
+func TestSecondaryPalette(t *testing.T) {
+	withTerminal(t, true)
+	if output := captureStdout(t, func() { PrintLavender("soft") }); output != Lavender.Wrap("soft")+"\n" {
+		t.Fatalf("unexpected output %q", output)
+	}
+	if SprintOrange("o") != Orange.Wrap("o") || SprintLime("l") != Lime.Wrap("l") || SprintMaroon("m") != Maroon.Wrap("m") {
+		t.Fatal("secondary Sprint helpers must use their colors")
+	}
+}
+
+func TestTerminalWidthDefault(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+	if width := terminalWidth(writer); width != 80 {
+		t.Fatalf("expected the default width for a pipe, got %v", width)
+	}
+}
+
+func TestSprintln(t *testing.T) {
+	line := Sprintln(Red, "block", 7, "sealed")
+	if line != string(Red)+"block 7 sealed"+string(Clear)+"\n" {
+		t.Fatalf("the reset must come before the newline, got %q", line)
+	}
+	if Mint.Sprintln() != Mint.Wrap("")+"\n" {
+		t.Fatalf("unexpected empty line %q", Mint.Sprintln())
+	}
+
+	withTerminal(t, true)
+	if output := captureStdout(t, func() { PrintlnColor(Blue, "a", "b") }); output != Blue.Wrap("a b")+"\n" {
+		t.Fatalf("unexpected output %q", output)
+	}
+	withTerminal(t, false)
+	if output := captureStdout(t, func() { PrintlnColor(Blue, "a", "b") }); output != "a b\n" {
+		t.Fatalf("expected plain output off a terminal, got %q", output)
+	}
+}
+
+func TestStandardColors(t *testing.T) {
+	normal := []Color{Black, "\033[31m", Green, "\033[33m", "\033[34m", Magenta, Cyan, White}
+	bright := []Color{BrightBlack, BrightRed, BrightGreen, BrightYellow, BrightBlue, BrightMagenta, BrightCyan, BrightWhite}
+	for i := range normal {
+		if normal[i] != Color(fmt.Sprintf("\033[%dm", 30+i)) {
+			t.Errorf("standard color %v is %q", i, normal[i])
+		}
+		if bright[i] != Color(fmt.Sprintf("\033[%dm", 90+i)) {
+			t.Errorf("bright color %v is %q", i, bright[i])
+		}
+	}
+	if Render("<cyan>c</cyan><bright-red>r</bright-red>") != Cyan.Wrap("c")+BrightRed.Wrap("r") {
+		t.Error("markup must support the standard colors")
+	}
+}
+
+func TestEprint(t *testing.T) {
+	withTerminal(t, true)
+	withStderrTerminal(t, false)
+
+	var stderr string
+	stdout := captureStdout(t, func() {
+		stderr = captureStderr(t, func() { EprintYellow("warning ", 1) })
+	})
+	if stdout != "" {
+		t.Errorf("stderr helper wrote %q to stdout", stdout)
+	}
+	if stderr != "warning 1\n" {
+		t.Errorf("piped stderr got %q", stderr)
+	}
+
+	withTerminal(t, false)
+	withStderrTerminal(t, true)
+	if stderr := captureStderr(t, func() { EprintRed("failed") }); stderr != Red.Wrap("failed")+"\n" {
+		t.Errorf("terminal stderr got %q", stderr)
+	}
+	if stderr := captureStderr(t, func() { Eprint(Pink, "a", "b") }); stderr != Pink.Wrap("ab")+"\n" {
+		t.Errorf("terminal stderr got %q", stderr)
+	}
+	if stdout := captureStdout(t, func() { PrintRed("failed") }); stdout != "failed\n" {
+		t.Errorf("piped stdout got %q", stdout)
+	}
+}
+
+func TestAdapt(t *testing.T) {
+	colored := Red.Wrap("error: ") + Hyperlink("https://example.com", "docs")
+	withTerminal(t, true)
+	if adapted := Adapt(colored); adapted != colored {
+		t.Errorf("terminal output lost its colors: %q", adapted)
+	}
+
+	Disable()
+	if adapted := Adapt(colored); adapted != "error: docs" {
+		t.Errorf("disabled colors kept: %q", adapted)
+	}
+	Enable()
+
+	withTerminal(t, false)
+	if adapted := Adapt(colored); adapted != "error: docs" {
+		t.Errorf("piped output kept colors: %q", adapted)
+	}
+	ForceColor(true)
+	defer ForceColor(false)
+	if adapted := Adapt(colored); adapted != colored {
+		t.Errorf("forced colors lost: %q", adapted)
+	}
+}
+
+func TestEnvironmentColors(t *testing.T) {
+	cases := []struct {
+		environment    map[string]string
+		goos           string
+		force, allowed bool
+	}{
+		{map[string]string{"TERM": "xterm"}, "linux", false, true},
+		{map[string]string{"TERM": "xterm", "NO_COLOR": "1"}, "linux", false, false},
+		{map[string]string{"TERM": "xterm", "CLICOLOR": "0"}, "linux", false, false},
+		{map[string]string{"TERM": "xterm", "CLICOLOR": "0", "CLICOLOR_FORCE": "1"}, "linux", true, true},
+		{map[string]string{"TERM": "xterm", "FORCE_COLOR": "2"}, "linux", true, true},
+		{map[string]string{"TERM": "xterm", "FORCE_COLOR": "0"}, "linux", false, false},
+		{map[string]string{"TERM": "xterm", "FORCE_COLOR": "0", "CLICOLOR_FORCE": "1"}, "linux", false, false},
+		{map[string]string{"TERM": "dumb"}, "linux", false, false},
+		{map[string]string{"TERM": "dumb"}, "windows", false, false},
+		{map[string]string{"TERM": "dumb", "FORCE_COLOR": ""}, "linux", true, true},
+		{map[string]string{"TERM": "dumb", "FORCE_COLOR": "3"}, "linux", true, true},
+		{map[string]string{"TERM": "dumb", "CLICOLOR_FORCE": "1"}, "linux", true, true},
+		{map[string]string{"TERM": "dumb", "FORCE_COLOR": "1", "NO_COLOR": "1"}, "linux", true, false},
+		{map[string]string{}, "linux", false, false},
+		{map[string]string{"FORCE_COLOR": "1"}, "linux", true, true},
+		{map[string]string{}, "windows", false, true},
+	}
+	for _, test := range cases {
+		lookup := func(key string) (string, bool) {
+			value, ok := test.environment[key]
+			return value, ok
+		}
+		force, allowed := environmentColors(lookup, test.goos)
+		if force != test.force || allowed != test.allowed {
+			t.Errorf("%v on %v gave force %v and allowed %v, expected %v and %v", test.environment, test.goos, force, allowed, test.force, test.allowed)
+		}
+	}
+}
+
+func TestNone(t *testing.T) {
+	text := "block 42\tok\n"
+	if None.Wrap(text) != text || None.Sprint("block ", 42) != "block 42" || Sprint(None, text) != text {
+		t.Error("None changed the text")
+	}
+	if None.Sprintf("%v", text) != text || None.Sprintln("a", "b") != "a b\n" {
+		t.Error("None changed the text")
+	}
+	var buffer bytes.Buffer
+	if _, err := Fprint(&buffer, None, text); err != nil || buffer.String() != text {
+		t.Errorf("None wrote %q", buffer.String())
+	}
+
+	withTerminal(t, true)
+	if output := captureStdout(t, func() { PrintlnColor(None, "plain") }); output != "plain\n" {
+		t.Errorf("None printed %q", output)
+	}
+}
+
+func TestWrapFgBg(t *testing.T) {
+	if ClearFg != "\033[39m" || ClearBg != "\033[49m" {
+		t.Errorf("unexpected resets %q %q", ClearFg, ClearBg)
+	}
+	if fg := Cyan.WrapFg("a"); fg != "\033[36ma\033[39m" {
+		t.Errorf("unexpected foreground %q", fg)
+	}
+	if bg := BgBlue.WrapBg("a"); bg != "\033[44ma\033[49m" {
+		t.Errorf("unexpected background %q", bg)
+	}
+
+	// a persistent background with changing foregrounds
+	line := BgBlue.Wrap(Yellow.WrapFg("warn") + " " + Cyan.WrapFg("info"))
+	var state sgrState
+	state.apply(string(BgBlue) + string(Yellow) + string(ClearFg))
+	if state.bg != "44" || state.fg != "" {
+		t.Errorf("foreground reset disturbed the background: %q", state.sequence())
+	}
+	if Uncolor(line) != "warn info" || StripColor(string(ClearFg)+"a"+string(ClearBg)) != "a" {
+		t.Errorf("targeted resets not stripped from %q", Uncolor(line))
+	}
+	if string(UncolorBytes([]byte(line))) != "warn info" {
+		t.Errorf("targeted resets not stripped from bytes")
+	}
+
+	if None.WrapFg("a") != "a" || None.WrapBg("a") != "a" {
+		t.Error("None changed the text")
+	}
+	Disable()
+	defer Enable()
+	if Cyan.WrapFg("a") != "a" || BgBlue.WrapBg("a") != "a" {
+		t.Error("disabled colors kept")
+	}
+}
+
+func TestPartialPalette(t *testing.T) {
+	defer SetPalette(DefaultPalette())
+	SetPalette(Palette{Red: Color256(160)})
+
+	if wrapped := Red.Wrap("error"); wrapped != string(Red)+"error"+string(Clear) {
+		t.Errorf("unexpected wrapping %q", wrapped)
+	}
+	if red := SprintRed("error"); red != string(Color256(160))+"error"+string(Clear) {
+		t.Errorf("unexpected palette color %q", red)
+	}
+	withLevel(t, LevelTrueColor)
+	for _, text := range []string{SprintRed("a"), Red.Sprintf("%v", 1), Gradient("fade", Red, Blue), Render("<red>a</red>")} {
+		if !Balanced(text) {
+			t.Errorf("%q leaves colors active", text)
+		}
+	}
+}
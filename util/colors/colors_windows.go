@@ -0,0 +1,27 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+//go:build windows
+
+package colors
+
+import "golang.org/x/sys/windows"
+
+// enableVirtualTerminal asks the console to interpret ANSI escape sequences, reporting
+// false if a console attached to stdout or stderr can't be switched into that mode.
+// Versions of Windows predating the mode reject the flag with an error.
+func enableVirtualTerminal() bool {
+	for _, handle := range []windows.Handle{windows.Stdout, windows.Stderr} {
+		var mode uint32
+		if err := windows.GetConsoleMode(handle, &mode); err != nil {
+			continue // not a console, so there's nothing to configure
+		}
+		if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+			continue
+		}
+		if err := windows.SetConsoleMode(handle, mode|windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING); err != nil {
+			return false
+		}
+	}
+	return true
+}
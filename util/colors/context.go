@@ -0,0 +1,44 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"context"
+	"fmt"
+)
+
+type colorsContextKey struct{}
+
+// WithColors returns a context that turns colors on or off for the context-aware helpers,
+// overriding the package-wide setting for whatever uses the context.
+func WithColors(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, colorsContextKey{}, enabled)
+}
+
+// EnabledFromContext reports whether colors are enabled for the context, falling back to
+// Enabled when the context doesn't say.
+func EnabledFromContext(ctx context.Context) bool {
+	if enabled, ok := ctx.Value(colorsContextKey{}).(bool); ok {
+		return enabled
+	}
+	return Enabled()
+}
+
+// SprintContext is Sprint, but colors the result only if the context has colors enabled.
+func SprintContext(ctx context.Context, color Color, args ...interface{}) string {
+	text := fmt.Sprint(args...)
+	if !EnabledFromContext(ctx) {
+		return text
+	}
+	return color.wrap(text)
+}
+
+// SprintfContext is Color.Sprintf, but colors the result only if the context has colors enabled.
+func SprintfContext(ctx context.Context, color Color, format string, args ...interface{}) string {
+	text := fmt.Sprintf(format, args...)
+	if !EnabledFromContext(ctx) {
+		return text
+	}
+	return color.wrap(text)
+}
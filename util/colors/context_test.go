@@ -0,0 +1,39 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextColors(t *testing.T) {
+	defer Enable()
+	colored := "\033[31;1mblock 7\033[0;0m"
+	plain := WithColors(context.Background(), false)
+	fancy := WithColors(context.Background(), true)
+
+	for _, globally := range []bool{true, false} {
+		SetEnabled(globally)
+		if EnabledFromContext(plain) || !EnabledFromContext(fancy) {
+			t.Fatal("the context's setting must override the global one")
+		}
+		if text := SprintContext(plain, Red, "block ", 7); text != "block 7" {
+			t.Errorf("expected plain text, got %q", text)
+		}
+		if text := SprintfContext(fancy, Red, "block %d", 7); text != colored {
+			t.Errorf("expected colored text, got %q", text)
+		}
+	}
+
+	absent := context.Background()
+	SetEnabled(true)
+	if !EnabledFromContext(absent) || SprintContext(absent, Red, "block 7") != colored {
+		t.Error("a context without a setting must follow Enabled")
+	}
+	SetEnabled(false)
+	if EnabledFromContext(absent) || SprintfContext(absent, Red, "block %v", 7) != "block 7" {
+		t.Error("a context without a setting must follow Enabled")
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "strings"
+
+// Diff compares the texts line by line, prefixing lines only in b with + and coloring them
+// mint, prefixing lines only in a with - and coloring them red, and indenting shared lines
+// with a space. The prefixes remain when colors are disabled so the diff reads well in logs.
+func Diff(a, b string) string {
+	before, after := diffLines(a), diffLines(b)
+
+	// common[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	common := make([][]int, len(before)+1)
+	for i := range common {
+		common[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	palette := CurrentPalette()
+	var builder strings.Builder
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			builder.WriteString(" " + before[i] + "\n")
+			i++
+			j++
+		case i < len(before) && (j == len(after) || common[i+1][j] >= common[i][j+1]):
+			builder.WriteString(palette.Red.Wrap("-"+before[i]) + "\n")
+			i++
+		default:
+			builder.WriteString(palette.Mint.Wrap("+"+after[j]) + "\n")
+			j++
+		}
+	}
+	return builder.String()
+}
+
+// diffLines splits the text into lines, ignoring a trailing newline.
+func diffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
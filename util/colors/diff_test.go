@@ -0,0 +1,34 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	added := func(line string) string { return Mint.Wrap("+"+line) + "\n" }
+	removed := func(line string) string { return Red.Wrap("-"+line) + "\n" }
+	cases := []struct {
+		name, a, b, diff string
+	}{
+		{"unchanged", "a\nb\n", "a\nb\n", " a\n b\n"},
+		{"insertion", "a\nc", "a\nb\nc", " a\n" + added("b") + " c\n"},
+		{"deletion", "a\nb\nc\n", "a\nc\n", " a\n" + removed("b") + " c\n"},
+		{"replacement", "chain: 1\nport: 8547\n", "chain: 1\nport: 8548\n", " chain: 1\n" + removed("port: 8547") + added("port: 8548")},
+		{"from nothing", "", "x\ny", added("x") + added("y")},
+		{"to nothing", "x", "", removed("x")},
+		{"both empty", "", "", ""},
+		{"appended", "a", "a\nb", " a\n" + added("b")},
+	}
+	for _, test := range cases {
+		if diff := Diff(test.a, test.b); diff != test.diff {
+			t.Errorf("%v: unexpected diff\n%q\nexpected\n%q", test.name, diff, test.diff)
+		}
+	}
+
+	Disable()
+	defer Enable()
+	if diff := Diff("a\nb\nc", "a\nB\nc"); diff != " a\n-b\n+B\n c\n" {
+		t.Errorf("unexpected plain diff %q", diff)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"hash/fnv"
+	"sync/atomic"
+)
+
+// minLuminance excludes colors too dark to read against a dark terminal background.
+const minLuminance = 0.1
+
+// selectable holds the colors of the 256-color palette that FromString chooses between.
+// The first 16 are skipped since terminals theme them, as are the grays, which are hard
+// to tell apart.
+var selectable = func() []uint8 {
+	var colors []uint8
+	for n := 16; n < 232; n++ {
+		if luminance(rgb256(uint8(n))) >= minLuminance {
+			colors = append(colors, uint8(n))
+		}
+	}
+	return colors
+}()
+
+// Palette256 lists the colors of the 256-color palette that FromString may return.
+func Palette256() []uint8 {
+	return append([]uint8{}, selectable...)
+}
+
+// FromString picks a color for the string by hashing it, so the same string, like the name of
+// a component, always gets the same color across runs and processes.
+func FromString(s string) Color {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(s)) // hashes never fail to write
+	return Color256(selectable[hash.Sum32()%uint32(len(selectable))])
+}
+
+// Cycler hands out colors in turn, wrapping around after the last, so adjacent items in a
+// list are easy to tell apart. The zero value cycles through the primary palette. It's safe
+// for concurrent use.
+type Cycler struct {
+	colors []Color
+	next   atomic.Uint64
+}
+
+// NewCycler makes a Cycler over the given colors, or over the primary palette if none are given.
+func NewCycler(colors ...Color) *Cycler {
+	if len(colors) == 0 {
+		colors = primaryColors()
+	}
+	return &Cycler{colors: append([]Color{}, colors...)}
+}
+
+// Next returns the next color in the cycle.
+func (c *Cycler) Next() Color {
+	colors := c.colors
+	if len(colors) == 0 {
+		colors = primaryColors()
+	}
+	return colors[(c.next.Add(1)-1)%uint64(len(colors))]
+}
+
+// primaryColors gives the colors of the current palette a Cycler defaults to.
+func primaryColors() []Color {
+	p := CurrentPalette()
+	return []Color{p.Red, p.Blue, p.Yellow, p.Pink, p.Mint, p.Grey}
+}
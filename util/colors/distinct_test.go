@@ -0,0 +1,80 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFromString(t *testing.T) {
+	// these must never change, since operators rely on components keeping their colors
+	expected := map[string]Color{
+		"sequencer":   "\033[38;5;205m",
+		"batchposter": "\033[38;5;29m",
+		"validator":   "\033[38;5;133m",
+	}
+	for name, color := range expected {
+		if FromString(name) != color {
+			t.Errorf("FromString(%q) = %q, expected %q", name, FromString(name), color)
+		}
+	}
+
+	allowed := make(map[Color]bool)
+	for _, n := range Palette256() {
+		allowed[Color256(n)] = true
+	}
+	for i := 0; i < 1000; i++ {
+		if color := FromString(fmt.Sprint("component-", i)); !allowed[color] {
+			t.Fatalf("FromString picked reserved color %q", color)
+		}
+	}
+}
+
+func TestPalette256(t *testing.T) {
+	reserved := map[uint8]bool{0: true, 1: true, 15: true, 16: true, 17: true, 18: true, 21: true, 232: true, 255: true}
+	for _, n := range Palette256() {
+		if reserved[n] {
+			t.Errorf("Palette256 includes reserved color %v", n)
+		}
+		if luminance(rgb256(n)) < minLuminance {
+			t.Errorf("Palette256 includes low-contrast color %v", n)
+		}
+	}
+	palette := Palette256()
+	palette[0] = 0
+	if Palette256()[0] == 0 {
+		t.Error("Palette256 must return a copy")
+	}
+}
+
+func TestCycler(t *testing.T) {
+	cycler := NewCycler(Red, Blue, Mint)
+	expected := []Color{Red, Blue, Mint, Red, Blue, Mint, Red}
+	for i, color := range expected {
+		if next := cycler.Next(); next != color {
+			t.Fatalf("color %v was %q, expected %q", i, next, color)
+		}
+	}
+
+	defaults := NewCycler()
+	for _, color := range []Color{Red, Blue, Yellow, Pink, Mint, Grey, Red} {
+		if next := defaults.Next(); next != color {
+			t.Fatalf("default cycle gave %q, expected %q", next, color)
+		}
+	}
+}
+
+func TestZeroCycler(t *testing.T) {
+	var cycler Cycler
+	expected := []Color{Red, Blue, Yellow, Pink, Mint, Grey, Red}
+	for i, color := range expected {
+		if next := cycler.Next(); next != color {
+			t.Errorf("color %v of a zero cycler is %q, expected %q", i, next, color)
+		}
+	}
+	if next := NewCycler([]Color{}...).Next(); next != Red {
+		t.Errorf("an empty cycler started with %q", next)
+	}
+}
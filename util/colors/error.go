@@ -0,0 +1,44 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"errors"
+	"strings"
+)
+
+// Error renders the error with its outermost message in red, followed by the messages of the
+// errors it wraps in grey, separated by arrows. So an error reading "start: open db: not found"
+// becomes "start → open db → not found". An error whose message doesn't end with that of the
+// error it wraps is shown whole, since its causes can't be split out. A nil error gives "".
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	messages := errorChain(err)
+	palette := CurrentPalette()
+	text := palette.Red.Wrap(messages[0])
+	if len(messages) > 1 {
+		text += palette.Grey.Wrap(" → " + strings.Join(messages[1:], " → "))
+	}
+	return text
+}
+
+// errorChain splits the error's message into the part each error in its chain contributes.
+func errorChain(err error) []string {
+	var messages []string
+	for err != nil {
+		message := err.Error()
+		inner := errors.Unwrap(err)
+		if inner == nil || !strings.HasSuffix(message, inner.Error()) {
+			return append(messages, message)
+		}
+		own := strings.TrimSuffix(strings.TrimSuffix(message, inner.Error()), ": ")
+		if own != "" {
+			messages = append(messages, own)
+		}
+		err = inner
+	}
+	return messages
+}
@@ -0,0 +1,37 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	if Error(nil) != "" {
+		t.Error("nil error should render empty")
+	}
+
+	single := errors.New("no such file")
+	if text := Error(single); text != Red.Wrap("no such file") {
+		t.Errorf("unexpected single error %q", text)
+	}
+
+	chain := fmt.Errorf("failed to start: %w", fmt.Errorf("open db: %w", fmt.Errorf("%w", single)))
+	if text := Error(chain); text != Red.Wrap("failed to start")+Grey.Wrap(" → open db → no such file") {
+		t.Errorf("unexpected chain %q", text)
+	}
+
+	unsplittable := fmt.Errorf("%w while opening db", single)
+	if text := Error(unsplittable); text != Red.Wrap("no such file while opening db") {
+		t.Errorf("unexpected error %q", text)
+	}
+
+	Disable()
+	defer Enable()
+	if text := Error(chain); text != "failed to start → open db → no such file" {
+		t.Errorf("unexpected plain chain %q", text)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Highlight colors each non-overlapping match of the pattern, leaving the rest of the text
+// untouched. Empty matches are skipped since there's nothing to color.
+func Highlight(text string, re *regexp.Regexp, c Color) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range re.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		if start == end {
+			continue
+		}
+		builder.WriteString(text[last:start])
+		builder.WriteString(c.Wrap(text[start:end]))
+		last = end
+	}
+	builder.WriteString(text[last:])
+	return builder.String()
+}
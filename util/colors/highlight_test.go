@@ -0,0 +1,34 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	hash := regexp.MustCompile(`0x[0-9a-f]+`)
+	text := "tx 0xabc included in 0xdef"
+	expected := "tx " + Mint.Wrap("0xabc") + " included in " + Mint.Wrap("0xdef")
+	if highlighted := Highlight(text, hash, Mint); highlighted != expected {
+		t.Errorf("unexpected highlighting %q", highlighted)
+	}
+
+	adjacent := regexp.MustCompile(`ab`)
+	if highlighted := Highlight("ababa", adjacent, Red); highlighted != Red.Wrap("ab")+Red.Wrap("ab")+"a" {
+		t.Errorf("unexpected highlighting of adjacent matches %q", highlighted)
+	}
+
+	optional := regexp.MustCompile(`x*`)
+	if highlighted := Highlight("axxbx", optional, Red); highlighted != "a"+Red.Wrap("xx")+"b"+Red.Wrap("x") {
+		t.Errorf("unexpected highlighting with empty matches %q", highlighted)
+	}
+	if highlighted := Highlight("", optional, Red); highlighted != "" {
+		t.Errorf("unexpected highlighting of the empty string %q", highlighted)
+	}
+	if highlighted := Highlight("none", hash, Red); highlighted != "none" {
+		t.Errorf("text without matches changed to %q", highlighted)
+	}
+}
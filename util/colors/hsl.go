@@ -0,0 +1,56 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "math"
+
+const (
+	distinctSaturation = 0.7 // the saturation of the colors Distinct picks
+	distinctLightness  = 0.6 // the lightness of the colors Distinct picks, readable on dark and light backgrounds
+)
+
+// HSL makes a 24-bit foreground color from a hue in degrees and a saturation and lightness
+// between 0 and 1. Hues wrap around the color wheel, while the saturation and lightness are
+// clamped. The print helpers downsample the color for terminals without truecolor support.
+func HSL(h, s, l float64) Color {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	s = math.Min(math.Max(s, 0), 1)
+	l = math.Min(math.Max(l, 0), 1)
+
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g = chroma, x
+	case h < 120:
+		r, g = x, chroma
+	case h < 180:
+		g, b = chroma, x
+	case h < 240:
+		g, b = x, chroma
+	case h < 300:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := l - chroma/2
+	channel := func(value float64) uint8 {
+		return uint8(math.Round((value + m) * 255))
+	}
+	return trueColor(38, channel(r), channel(g), channel(b))
+}
+
+// Distinct picks n colors with evenly spaced hues, starting from red, for telling apart many
+// things at once, like log streams. It returns an empty slice if n isn't positive.
+func Distinct(n int) []Color {
+	colors := make([]Color, 0, max(n, 0))
+	for i := 0; i < n; i++ {
+		colors = append(colors, HSL(360*float64(i)/float64(n), distinctSaturation, distinctLightness))
+	}
+	return colors
+}
@@ -0,0 +1,76 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHSL(t *testing.T) {
+	cases := []struct {
+		h, s, l float64
+		color   Color
+	}{
+		{0, 1, 0.5, "\033[38;2;255;0;0m"},
+		{120, 1, 0.5, "\033[38;2;0;255;0m"},
+		{240, 1, 0.5, "\033[38;2;0;0;255m"},
+		{60, 1, 0.25, "\033[38;2;128;128;0m"},
+		{200, 0, 0.5, "\033[38;2;128;128;128m"},
+		{0, 0, 1, "\033[38;2;255;255;255m"},
+		{480, 1, 0.5, "\033[38;2;0;255;0m"},
+		{-120, 1, 0.5, "\033[38;2;0;0;255m"},
+		{0, 2, 0.5, "\033[38;2;255;0;0m"},
+		{0, 1, -1, "\033[38;2;0;0;0m"},
+	}
+	for _, test := range cases {
+		if color := HSL(test.h, test.s, test.l); color != test.color {
+			t.Errorf("HSL(%v, %v, %v) = %q, expected %q", test.h, test.s, test.l, color, test.color)
+		}
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	for _, n := range []int{-1, 0} {
+		if colors := Distinct(n); colors == nil || len(colors) != 0 {
+			t.Errorf("Distinct(%v) gave %q", n, colors)
+		}
+	}
+
+	for _, n := range []int{1, 3, 7, 12} {
+		colors := Distinct(n)
+		if len(colors) != n {
+			t.Fatalf("Distinct(%v) gave %v colors", n, len(colors))
+		}
+		for i, color := range colors {
+			r, g, b, ok := colorRGB(color)
+			if !ok {
+				t.Fatalf("can't parse %q", color)
+			}
+			expected := 360 * float64(i) / float64(n)
+			if h := hue(r, g, b); math.Abs(h-expected) > 1 {
+				t.Errorf("color %v of %v has hue %v, expected %v", i, n, h, expected)
+			}
+		}
+	}
+}
+
+// hue gives the hue of the color in degrees.
+func hue(r, g, b uint8) float64 {
+	red, green, blue := float64(r), float64(g), float64(b)
+	high := math.Max(red, math.Max(green, blue))
+	chroma := high - math.Min(red, math.Min(green, blue))
+	var h float64
+	switch {
+	case chroma == 0:
+		return 0
+	case high == red:
+		h = math.Mod((green-blue)/chroma, 6)
+	case high == green:
+		h = (blue-red)/chroma + 2
+	default:
+		h = (red-green)/chroma + 4
+	}
+	return math.Mod(h*60+360, 360)
+}
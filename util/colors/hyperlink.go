@@ -0,0 +1,14 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+// Hyperlink makes the text a clickable link to the url using an OSC 8 sequence, which modern
+// terminals understand. The text is returned as is when stdout wouldn't be colored, so logs
+// and older terminals don't receive the sequence.
+func Hyperlink(url, text string) string {
+	if !colorStdout() {
+		return text
+	}
+	return "\033]8;;" + url + "\033\\" + text + "\033]8;;\033\\"
+}
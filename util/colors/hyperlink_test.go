@@ -0,0 +1,24 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "testing"
+
+func TestHyperlink(t *testing.T) {
+	url := "https://arbiscan.io/block/42"
+
+	withTerminal(t, true)
+	link := Hyperlink(url, "block 42")
+	if link != "\033]8;;https://arbiscan.io/block/42\033\\block 42\033]8;;\033\\" {
+		t.Fatalf("unexpected hyperlink %q", link)
+	}
+	if StripColor(link) != "block 42" || Uncolor(Blue.Wrap(link)) != "block 42" {
+		t.Fatalf("hyperlink wasn't stripped: %q", StripColor(link))
+	}
+
+	withTerminal(t, false)
+	if link := Hyperlink(url, "block 42"); link != "block 42" {
+		t.Fatalf("expected plain text off a terminal, got %q", link)
+	}
+}
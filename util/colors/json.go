@@ -0,0 +1,96 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// names maps escape sequences back to the names in named. Where several names share a
+// sequence, like grey and bright-black, the shortest wins.
+var names = func() map[Color]string {
+	names := make(map[Color]string)
+	for name, color := range named {
+		prior, ok := names[color]
+		if !ok || len(name) < len(prior) || (len(name) == len(prior) && name < prior) {
+			names[color] = name
+		}
+	}
+	return names
+}()
+
+var rgbFunction = regexp.MustCompile(`^rgb\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*\)$`)
+
+// MarshalJSON encodes the color as its name, like "red", or otherwise as a hex code like
+// "#ffaa00". Backgrounds are prefixed with "bg-".
+func (c Color) MarshalJSON() ([]byte, error) {
+	if name, ok := names[c]; ok || c == "" {
+		return json.Marshal(name)
+	}
+	params := sgrParams(string(c))
+	if len(params) == 1 {
+		if r, g, b, ok := paramRGB(params[0]); ok {
+			token := fmt.Sprintf("#%02x%02x%02x", r, g, b)
+			if strings.HasPrefix(params[0], "4") || strings.HasPrefix(params[0], "10") {
+				token = "bg-" + token
+			}
+			return json.Marshal(token)
+		}
+	}
+	return nil, fmt.Errorf("colors: can't name color %q", string(c))
+}
+
+// UnmarshalJSON decodes a color's name, a hex code like "#ffaa00" or "#fa0", or an rgb() form
+// like "rgb(255, 170, 0)". Prefixing a hex code or rgb() form with "bg-" makes a background.
+func (c *Color) UnmarshalJSON(data []byte) error {
+	var token string
+	if err := json.Unmarshal(data, &token); err != nil {
+		return err
+	}
+	color, err := parseColor(token)
+	if err != nil {
+		return err
+	}
+	*c = color
+	return nil
+}
+
+func parseColor(token string) (Color, error) {
+	token = strings.ToLower(strings.TrimSpace(token))
+	if token == "" {
+		return "", nil
+	}
+	if color, ok := named[token]; ok {
+		return color, nil
+	}
+	code := 38
+	spec := token
+	if rest, ok := strings.CutPrefix(token, "bg-"); ok {
+		code, spec = 48, rest
+	}
+	if hex, ok := strings.CutPrefix(spec, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if value, err := strconv.ParseUint(hex, 16, 24); err == nil && len(hex) == 6 {
+			return trueColor(code, uint8(value>>16), uint8(value>>8), uint8(value)), nil
+		}
+	}
+	if match := rgbFunction.FindStringSubmatch(spec); match != nil {
+		var channels [3]uint8
+		for i := range channels {
+			value, err := strconv.ParseUint(match[i+1], 10, 8)
+			if err != nil {
+				return "", fmt.Errorf("colors: channel out of range in %q", token)
+			}
+			channels[i] = uint8(value)
+		}
+		return trueColor(code, channels[0], channels[1], channels[2]), nil
+	}
+	return "", fmt.Errorf("colors: unknown color %q", token)
+}
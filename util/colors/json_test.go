@@ -0,0 +1,75 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestColorJSON(t *testing.T) {
+	cases := []struct {
+		input   string
+		color   Color
+		encoded string
+	}{
+		{`"red"`, Red, `"red"`},
+		{`"Mint"`, Mint, `"mint"`},
+		{`"bright-black"`, Grey, `"grey"`},
+		{`"bg-lavender"`, BgLavender, `"bg-lavender"`},
+		{`"#ffaa00"`, "\033[38;2;255;170;0m", `"#ffaa00"`},
+		{`"#FA0"`, "\033[38;2;255;170;0m", `"#ffaa00"`},
+		{`"rgb(1, 2, 3)"`, "\033[38;2;1;2;3m", `"#010203"`},
+		{`"bg-rgb(10,20,30)"`, "\033[48;2;10;20;30m", `"bg-#0a141e"`},
+		{`"bg-#000000"`, "\033[48;2;0;0;0m", `"bg-#000000"`},
+		{`""`, "", `""`},
+	}
+	for _, test := range cases {
+		var color Color
+		if err := json.Unmarshal([]byte(test.input), &color); err != nil {
+			t.Errorf("failed to decode %v: %v", test.input, err)
+			continue
+		}
+		if color != test.color {
+			t.Errorf("decoded %v as %q, expected %q", test.input, color, test.color)
+		}
+		encoded, err := json.Marshal(color)
+		if err != nil {
+			t.Errorf("failed to encode %q: %v", color, err)
+			continue
+		}
+		if string(encoded) != test.encoded {
+			t.Errorf("encoded %q as %s, expected %s", color, encoded, test.encoded)
+		}
+		var again Color
+		if err := json.Unmarshal(encoded, &again); err != nil || again != color {
+			t.Errorf("%s didn't round-trip: got %q, %v", encoded, again, err)
+		}
+	}
+
+	encoded, err := json.Marshal(Color256(208))
+	if err != nil || string(encoded) != `"#ff8700"` {
+		t.Errorf("encoded a 256-color as %s, %v", encoded, err)
+	}
+
+	theme := map[string]Color{}
+	if err := json.Unmarshal([]byte(`{"error":"red","warn":"#ffaa00"}`), &theme); err != nil {
+		t.Fatal(err)
+	}
+	if theme["error"] != Red || theme["warn"] != "\033[38;2;255;170;0m" {
+		t.Errorf("unexpected theme %q", theme)
+	}
+}
+
+func TestColorJSONErrors(t *testing.T) {
+	for _, input := range []string{`"chartreuse"`, `"#12345"`, `"#ggg"`, `"rgb(256, 0, 0)"`, `"rgb(1,2)"`, `7`} {
+		var color Color
+		if err := json.Unmarshal([]byte(input), &color); err == nil {
+			t.Errorf("decoding %v should fail, got %q", input, color)
+		}
+	}
+	if _, err := json.Marshal(Style(Underline, Red)); err == nil {
+		t.Error("encoding a style without a name should fail")
+	}
+}
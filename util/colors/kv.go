@@ -0,0 +1,26 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "strings"
+
+// KV renders a "key: value" pair, coloring the key like the theme colors log attribute keys.
+func KV(key, value string) string {
+	return CurrentTheme().Key.Wrap(key) + ": " + value
+}
+
+// KVList renders each pair on its own line like KV, padding the keys to a common visible width
+// so the values line up even when keys contain escape sequences or wide characters.
+func KVList(pairs ...[2]string) string {
+	width := 0
+	for _, pair := range pairs {
+		width = max(width, VisibleWidth(pair[0]))
+	}
+	lines := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		padding := strings.Repeat(" ", width-VisibleWidth(pair[0]))
+		lines = append(lines, KV(pair[0], padding+pair[1]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,39 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKV(t *testing.T) {
+	if kv := KV("chain", "42161"); kv != Grey.Wrap("chain")+": 42161" {
+		t.Errorf("unexpected pair %q", kv)
+	}
+
+	list := KVList(
+		[2]string{"chain", "42161"},
+		[2]string{Red.Wrap("status"), "syncing"},
+		[2]string{"latest block", "1234"},
+		[2]string{"名前", "nitro"},
+	)
+	expected := "chain:        42161\nstatus:       syncing\nlatest block: 1234\n名前:         nitro"
+	if plain := StripColor(list); plain != expected {
+		t.Errorf("misaligned list\n%v\nexpected\n%v", plain, expected)
+	}
+	lines := strings.Split(list, "\n")
+	if lines[1] != Grey.Wrap(Red.Wrap("status"))+":       syncing" {
+		t.Errorf("unexpected colored line %q", lines[1])
+	}
+	if KVList() != "" {
+		t.Error("empty list should render empty")
+	}
+
+	Disable()
+	defer Enable()
+	if list := KVList([2]string{"a", "1"}, [2]string{"bcd", "2"}); list != "a:   1\nbcd: 2" {
+		t.Errorf("unexpected plain list %q", list)
+	}
+}
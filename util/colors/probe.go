@@ -0,0 +1,57 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"time"
+)
+
+// probeTimeout bounds how long ProbeTruecolor waits for the terminal to answer.
+const probeTimeout = 200 * time.Millisecond
+
+// probeQuery sets a truecolor background, asks the terminal which rendition is active with
+// DECRQSS, then asks for the cursor position, which every terminal answers. A terminal
+// supporting truecolor reports the background it was given.
+const probeQuery = "\033[48;2;1;2;3m\033P$qm\033\\\033[6n" + probeCleanup
+
+// probeCleanup resets the background afterward, along with the faint, bold, and italic text a
+// terminal not understanding 48;2 would take the rest of its parameters for.
+const probeCleanup = "\033[22;23;49m"
+
+var (
+	probedTruecolor = regexp.MustCompile(`48[:;]2[:;]+1[:;]2[:;]3`)
+	cursorPosition  = regexp.MustCompile(`\x1b\[[0-9]+;[0-9]+R`)
+)
+
+// ProbeTruecolor asks the terminal whether it supports truecolor rather than trusting TERM and
+// COLORTERM, waiting a fraction of a second for the answer. The terminal's mode is
+// restored before returning. When stdin and stdout aren't both terminals, or the terminal
+// doesn't answer in time or can't report its rendition, it falls back to what the environment
+// advertises. The probe only runs
+// when called, so it never slows down startup.
+func ProbeTruecolor() bool {
+	if supported, answered := probe(os.Stdin, os.Stdout, probeTimeout); answered {
+		return supported
+	}
+	return Level() >= LevelTrueColor
+}
+
+// interpretProbe reports whether the terminal's reply to probeQuery is complete, and if so
+// whether it's conclusive and shows truecolor support. A reply is only conclusive if the
+// terminal understood the DECRQSS request, so one without an answer to it, or with the 0$r of
+// a request it didn't understand, says nothing about truecolor.
+func interpretProbe(reply []byte) (supported, conclusive, complete bool) {
+	position := cursorPosition.FindIndex(reply)
+	if position == nil {
+		return false, false, false
+	}
+	rendition := reply[:position[0]]
+	if !bytes.Contains(rendition, []byte("\033P1$r")) {
+		return false, false, true
+	}
+	return probedTruecolor.Match(rendition), true, true
+}
@@ -0,0 +1,81 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// answerProbe replies to the probe after the delay, once the pseudo-terminal has received all of it.
+func answerProbe(t *testing.T, master *os.File, reply string, delay time.Duration) {
+	t.Helper()
+	go func() {
+		var received []byte
+		buffer := make([]byte, 256)
+		for !bytes.Contains(received, []byte(probeQuery)) {
+			n, err := master.Read(buffer)
+			if err != nil {
+				return
+			}
+			received = append(received, buffer[:n]...)
+		}
+		time.Sleep(delay)
+		_, _ = master.WriteString(reply)
+	}()
+}
+
+func TestProbeTimeout(t *testing.T) {
+	master, slave := openPTY(t)
+	before, err := unix.IoctlGetTermios(int(slave.Fd()), unix.TCGETS)
+	if err != nil {
+		t.Skip("failed to read terminal mode:", err)
+	}
+
+	timeout := 50 * time.Millisecond
+	answerProbe(t, master, "\033P1$r0;48:2:1:2:3m\033\\\033[1;1R", timeout*3/2)
+	start := time.Now()
+	if _, answered := probe(slave, slave, timeout); answered {
+		t.Error("the probe took a late answer")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("probe took %v despite its timeout", elapsed)
+	}
+
+	after, err := unix.IoctlGetTermios(int(slave.Fd()), unix.TCGETS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *after != *before {
+		t.Error("probe didn't restore the terminal's mode")
+	}
+	pending := []unix.PollFd{{Fd: int32(slave.Fd()), Events: unix.POLLIN}}
+	if ready, err := unix.Poll(pending, 0); err != nil || ready != 0 {
+		t.Error("the late answer was left in the terminal's input", ready, err)
+	}
+}
+
+func TestProbeAnswered(t *testing.T) {
+	cases := []struct {
+		reply               string
+		supported, answered bool
+	}{
+		{"\033P1$r0;48:2:1:2:3m\033\\\033[1;1R", true, true},
+		{"\033P1$r0;48;5;16m\033\\\033[1;1R", false, true},
+		{"\033P0$r\033\\\033[1;1R", false, false},
+		{"\033[1;1R", false, false},
+	}
+	for _, test := range cases {
+		master, slave := openPTY(t)
+		answerProbe(t, master, test.reply, 0)
+		supported, answered := probe(slave, slave, 5*time.Second)
+		if answered != test.answered || supported != test.supported {
+			t.Errorf("reply %q gave %v %v, expected %v %v", test.reply, supported, answered, test.supported, test.answered)
+		}
+	}
+}
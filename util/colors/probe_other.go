@@ -0,0 +1,17 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+//go:build !unix
+
+package colors
+
+import (
+	"os"
+	"time"
+)
+
+// probe never gets an answer, since reading the terminal's reply with a timeout needs raw
+// input that only unix-like systems provide here.
+func probe(in, out *os.File, timeout time.Duration) (supported, answered bool) {
+	return false, false
+}
@@ -0,0 +1,62 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestInterpretProbe(t *testing.T) {
+	cases := []struct {
+		reply                           string
+		supported, conclusive, complete bool
+	}{
+		{"\033P1$r0;48:2:1:2:3m\033\\\033[12;1R", true, true, true},
+		{"\033P1$r48;2;1;2;3m\033\\\033[1;80R", true, true, true},
+		{"\033P1$r48:2::1:2:3m\033\\\033[1;1R", true, true, true},
+		{"\033P1$r0;48;5;16m\033\\\033[1;1R", false, true, true},
+		{"\033P0$r\033\\\033[3;4R", false, false, true},
+		{"\033[3;4R", false, false, true},
+		{"\033P1$r0;48:2:1:2:3m\033\\", false, false, false},
+		{"\033[3;4", false, false, false},
+		{"", false, false, false},
+	}
+	for _, test := range cases {
+		supported, conclusive, complete := interpretProbe([]byte(test.reply))
+		if supported != test.supported || conclusive != test.conclusive || complete != test.complete {
+			t.Errorf("reply %q gave %v %v %v, expected %v %v %v", test.reply, supported, conclusive, complete,
+				test.supported, test.conclusive, test.complete)
+		}
+	}
+}
+
+func TestProbeCleanup(t *testing.T) {
+	// what terminals with and without truecolor take the query's rendition for
+	for _, rendition := range []string{"\033[48;2;1;2;3m", "\033[2;1;2;3m"} {
+		var state sgrState
+		state.apply(rendition + probeCleanup)
+		if state.active() {
+			t.Errorf("the probe's cleanup left %q after %q", state.sequence(), rendition)
+		}
+	}
+}
+
+func TestProbeNotTerminal(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	start := time.Now()
+	if _, answered := probe(reader, writer, time.Minute); answered {
+		t.Error("a pipe answered the probe")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("probing a pipe took %v", elapsed)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+//go:build unix
+
+package colors
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"golang.org/x/term"
+)
+
+// probe sends probeQuery to the terminal and reads its reply with raw input, reporting whether
+// the terminal answered conclusively before the timeout and whether it supports truecolor. A terminal that
+// answers after the timeout would leave its reply in stdin, so in that case probe waits as long
+// again for the answer and discards it, along with any other pending input, before restoring
+// the terminal's mode.
+func probe(in, out *os.File, timeout time.Duration) (supported, answered bool) {
+	if !isTerminal(in) || !isTerminal(out) {
+		return false, false
+	}
+	fd := int(in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return false, false
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+
+	if _, err := out.WriteString(probeQuery); err != nil {
+		return false, false
+	}
+
+	if supported, conclusive, complete := interpretProbe(awaitReply(fd, time.Now().Add(timeout))); complete {
+		return supported, conclusive
+	}
+	awaitReply(fd, time.Now().Add(timeout))
+	discardInput(fd)
+	return false, false
+}
+
+// awaitReply reads from the terminal until it has given a complete reply to probeQuery, the
+// deadline passes, or reading fails.
+func awaitReply(fd int, deadline time.Time) []byte {
+	var reply []byte
+	buffer := make([]byte, 256)
+	for {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return reply
+		}
+		fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+		ready, err := unix.Poll(fds, int(max(remaining.Milliseconds(), 1)))
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil || ready == 0 {
+			return reply
+		}
+		n, err := unix.Read(fd, buffer)
+		if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil || n == 0 {
+			return reply
+		}
+		reply = append(reply, buffer[:n]...)
+		if _, _, complete := interpretProbe(reply); complete {
+			return reply
+		}
+	}
+}
+
+// discardInput reads and drops whatever input the terminal has pending, like tcflush would.
+func discardInput(fd int) {
+	buffer := make([]byte, 256)
+	for {
+		fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+		ready, err := unix.Poll(fds, 0)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil || ready == 0 || fds[0].Revents&unix.POLLIN == 0 {
+			return
+		}
+		if n, err := unix.Read(fd, buffer); n <= 0 && !errors.Is(err, unix.EINTR) {
+			return
+		}
+	}
+}
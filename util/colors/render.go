@@ -0,0 +1,110 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "strings"
+
+// named maps the names of colors and styles, as used in markup, to their escape sequences.
+var named = map[string]Color{
+	"red": Red, "blue": Blue, "yellow": Yellow, "pink": Pink, "mint": Mint, "grey": Grey,
+	"lime": Lime, "lavender": Lavender, "maroon": Maroon, "orange": Orange,
+	"black": Black, "green": Green, "magenta": Magenta, "cyan": Cyan, "white": White,
+	"bright-black": BrightBlack, "bright-red": BrightRed, "bright-green": BrightGreen,
+	"bright-yellow": BrightYellow, "bright-blue": BrightBlue, "bright-magenta": BrightMagenta,
+	"bright-cyan": BrightCyan, "bright-white": BrightWhite,
+
+	"bg-red": BgRed, "bg-blue": BgBlue, "bg-yellow": BgYellow, "bg-pink": BgPink, "bg-mint": BgMint,
+	"bg-grey": BgGrey, "bg-lime": BgLime, "bg-lavender": BgLavender, "bg-maroon": BgMaroon,
+	"bg-orange": BgOrange,
+
+	"bold": Bold, "dim": Dim, "italic": Italic, "underline": Underline, "blink": Blink,
+}
+
+// tagged resolves a markup tag, taking the colors a palette has from p rather than named.
+func tagged(p Palette, tag string) (Color, bool) {
+	switch tag {
+	case "red":
+		return p.Red, true
+	case "blue":
+		return p.Blue, true
+	case "yellow":
+		return p.Yellow, true
+	case "pink":
+		return p.Pink, true
+	case "mint":
+		return p.Mint, true
+	case "grey":
+		return p.Grey, true
+	case "lime":
+		return p.Lime, true
+	case "lavender":
+		return p.Lavender, true
+	case "maroon":
+		return p.Maroon, true
+	case "orange":
+		return p.Orange, true
+	}
+	color, ok := named[tag]
+	return color, ok
+}
+
+// Render replaces markup like <red>text</red> or <bold>text</bold> with escape sequences.
+// Tags nest, so closing an inner tag restores the outer tag's styling rather than the default.
+// Unknown tags and closing tags that match nothing open are left as is, and \< gives a
+// literal <. Color names resolve through the current palette, and every reset is its Clear.
+// When colors are disabled the tags are removed without adding escape sequences.
+func Render(markup string) string {
+	p := CurrentPalette()
+	var builder strings.Builder
+	var names []string
+	var open []Color
+	emit := func(color Color) {
+		if Enabled() {
+			builder.WriteString(string(color))
+		}
+	}
+
+	for i := 0; i < len(markup); {
+		if strings.HasPrefix(markup[i:], `\<`) {
+			builder.WriteByte('<')
+			i += 2
+			continue
+		}
+		if markup[i] == '<' {
+			if length := strings.IndexByte(markup[i:], '>'); length > 0 {
+				tag := markup[i+1 : i+length]
+				if name, closing := strings.CutPrefix(tag, "/"); closing {
+					if depth := lastIndex(names, name); depth >= 0 {
+						names, open = names[:depth], open[:depth]
+						emit(p.Clear)
+						emit(Style(open...))
+						i += length + 1
+						continue
+					}
+				} else if color, ok := tagged(p, tag); ok {
+					names, open = append(names, tag), append(open, color)
+					emit(color)
+					i += length + 1
+					continue
+				}
+			}
+		}
+		builder.WriteByte(markup[i])
+		i++
+	}
+	if len(open) > 0 {
+		emit(p.Clear)
+	}
+	return builder.String()
+}
+
+// lastIndex finds the last occurrence of the string in the slice, or returns -1.
+func lastIndex(slice []string, s string) int {
+	for i := len(slice) - 1; i >= 0; i-- {
+		if slice[i] == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,49 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import "testing"
+
+func TestRender(t *testing.T) {
+	red, blue, bold, reset := string(Red), string(Blue), string(Bold), string(Clear)
+	cases := []struct {
+		markup   string
+		rendered string
+	}{
+		{"plain", "plain"},
+		{"<red>alert</red>!", red + "alert" + reset + "!"},
+		{"<red>a<bold>b</bold>c</red>", red + "a" + bold + "b" + reset + red + "c" + reset},
+		{"<red>a<blue>b</blue>c</red>d", red + "a" + blue + "b" + reset + red + "c" + reset + "d"},
+		{"<bold>a<red>b<blue>c</blue>d</red>e</bold>", bold + "a" + red + "b" + blue + "c" + reset + red + "d" + reset + bold + "e" + reset},
+		{"<red>unclosed", red + "unclosed" + reset},
+		{"<nope>x</nope> </red> <red", "<nope>x</nope> </red> <red"},
+		{`\<red>literal\</red> 1 < 2`, "<red>literal</red> 1 < 2"},
+		{"<red>a<blue>b</red>c", red + "a" + blue + "b" + reset + "c"},
+		{"<red><blue><red><bold>x</bold>y", red + blue + red + bold + "x" + reset + "\033[34;31;1m" + "y" + reset},
+	}
+	for _, test := range cases {
+		if rendered := Render(test.markup); rendered != test.rendered {
+			t.Errorf("Render(%q) = %q, expected %q", test.markup, rendered, test.rendered)
+		}
+	}
+
+	Disable()
+	defer Enable()
+	if rendered := Render("<red>a<bold>b</bold></red> \\<c>"); rendered != "ab <c>" {
+		t.Errorf("expected plain text when disabled, got %q", rendered)
+	}
+}
+
+func TestRenderPalette(t *testing.T) {
+	previous := CurrentPalette()
+	defer SetPalette(previous)
+	p := DefaultPalette()
+	p.Red, p.Clear = BrightRed, "\033[0m"
+	SetPalette(p)
+
+	rendered := Render("<red>a<bold>b</bold></red>")
+	if expected := string(BrightRed) + "a" + string(Bold) + "b\033[0m" + string(BrightRed) + "\033[0m"; rendered != expected {
+		t.Errorf("rendered %q with a custom palette, expected %q", rendered, expected)
+	}
+}
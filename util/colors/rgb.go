@@ -0,0 +1,213 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGB makes a 24-bit foreground color, or the closest color the terminal can display when
+// it doesn't support truecolor.
+func RGB(r, g, b uint8) Color {
+	return Downsample(trueColor(38, r, g, b))
+}
+
+// BgRGB makes a 24-bit background color, or the closest color the terminal can display when
+// it doesn't support truecolor.
+func BgRGB(r, g, b uint8) Color {
+	return Downsample(trueColor(48, r, g, b))
+}
+
+// trueColor makes a 24-bit color regardless of what the terminal supports. The code is 38 for
+// the foreground or 48 for the background.
+func trueColor(code int, r, g, b uint8) Color {
+	return Color(fmt.Sprintf("\033[%d;2;%d;%d;%dm", code, r, g, b))
+}
+
+// Color256 makes a foreground color from the 256-color palette.
+func Color256(n uint8) Color {
+	return Color(fmt.Sprintf("\033[38;5;%dm", n))
+}
+
+// Gradient fades the text from one color to the other, giving each rune its own 24-bit color.
+// Terminals without truecolor support get the whole text in the first color, as does text too
+// short to fade.
+func Gradient(text string, from, to Color) string {
+	runes := []rune(text)
+	fromR, fromG, fromB, fromOk := colorRGB(from)
+	toR, toG, toB, toOk := colorRGB(to)
+	if len(runes) < 2 || colorLevel < LevelTrueColor || !fromOk || !toOk {
+		if text == "" {
+			return ""
+		}
+		return from.Wrap(text)
+	}
+	if !Enabled() {
+		return text
+	}
+
+	var builder strings.Builder
+	for i, r := range runes {
+		t := float64(i) / float64(len(runes)-1)
+		builder.WriteString(string(trueColor(38, lerp(fromR, toR, t), lerp(fromG, toG, t), lerp(fromB, toB, t))))
+		builder.WriteRune(r)
+	}
+	builder.WriteString(string(CurrentPalette().Clear))
+	return builder.String()
+}
+
+// Mix blends two colors, moving from a toward b by the fraction t, which is clamped between 0
+// and 1. The result is a 24-bit foreground color, except that the ends of the range give a and b
+// as they are. Should either color not be parsable, the closer of the two is returned.
+func Mix(a, b Color, t float64) Color {
+	t = math.Min(math.Max(t, 0), 1)
+	aR, aG, aB, aOk := colorRGB(a)
+	bR, bG, bB, bOk := colorRGB(b)
+	switch {
+	case t == 0:
+		return a
+	case t == 1:
+		return b
+	case !aOk || !bOk:
+		if t < 0.5 {
+			return a
+		}
+		return b
+	}
+	return trueColor(38, lerp(aR, bR, t), lerp(aG, bG, t), lerp(aB, bB, t))
+}
+
+// lerp interpolates between two channel values.
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+}
+
+// The first 16 colors of the 256-color palette, as xterm renders them.
+var basicRGB = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// The intensities of each channel in the palette's 6x6x6 color cube.
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// rgb256 gives the components of a color in the 256-color palette.
+func rgb256(n uint8) (uint8, uint8, uint8) {
+	switch {
+	case n < 16:
+		return basicRGB[n][0], basicRGB[n][1], basicRGB[n][2]
+	case n < 232:
+		n -= 16
+		return cubeLevels[n/36], cubeLevels[n/6%6], cubeLevels[n%6]
+	default:
+		gray := 8 + 10*(n-232)
+		return gray, gray, gray
+	}
+}
+
+// nearest256 finds the color of the 256-color palette closest to the given one. The first 16
+// colors are skipped since terminals commonly theme them.
+func nearest256(r, g, b uint8) uint8 {
+	best, bestDistance := uint8(16), -1
+	for n := 16; n < 256; n++ {
+		pr, pg, pb := rgb256(uint8(n))
+		distance := square(int(r)-int(pr)) + square(int(g)-int(pg)) + square(int(b)-int(pb))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = uint8(n), distance
+		}
+	}
+	return best
+}
+
+// nearestBasic finds which of the 8 standard colors is closest to the given one.
+func nearestBasic(r, g, b uint8) int {
+	best, bestDistance := 0, -1
+	for n := 0; n < 8; n++ {
+		pr, pg, pb := rgb256(uint8(n))
+		distance := square(int(r)-int(pr)) + square(int(g)-int(pg)) + square(int(b)-int(pb))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = n, distance
+		}
+	}
+	return best
+}
+
+func square(x int) int {
+	return x * x
+}
+
+// luminance computes the relative luminance of a color as defined by WCAG, ranging from 0 for
+// black to 1 for white.
+func luminance(r, g, b uint8) float64 {
+	linear := func(channel uint8) float64 {
+		c := float64(channel) / 255
+		if c <= 0.03928 {
+			return c / 12.92
+		}
+		return math.Pow((c+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(r) + 0.7152*linear(g) + 0.0722*linear(b)
+}
+
+// colorRGB finds the components of the first foreground or background color the sequence sets.
+func colorRGB(c Color) (r, g, b uint8, ok bool) {
+	for _, param := range sgrParams(string(c)) {
+		if r, g, b, ok := paramRGB(param); ok {
+			return r, g, b, true
+		}
+	}
+	return 0, 0, 0, false
+}
+
+// paramRGB finds the components of the color an SGR parameter sets, if it sets one.
+func paramRGB(param string) (r, g, b uint8, ok bool) {
+	parts := strings.Split(param, ";")
+	values := make([]uint8, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		values[i] = uint8(value)
+	}
+	code := int(values[0])
+	switch {
+	case len(values) == 5 && (code == 38 || code == 48) && values[1] == 2:
+		return values[2], values[3], values[4], true
+	case len(values) == 3 && (code == 38 || code == 48) && values[1] == 5:
+		r, g, b := rgb256(values[2])
+		return r, g, b, true
+	case len(values) > 1:
+		return 0, 0, 0, false
+	case code >= 30 && code <= 37:
+		r, g, b := rgb256(uint8(code - 30))
+		return r, g, b, true
+	case code >= 40 && code <= 47:
+		r, g, b := rgb256(uint8(code - 40))
+		return r, g, b, true
+	case code >= 90 && code <= 97:
+		r, g, b := rgb256(uint8(code - 82))
+		return r, g, b, true
+	case code >= 100 && code <= 107:
+		r, g, b := rgb256(uint8(code - 92))
+		return r, g, b, true
+	}
+	return 0, 0, 0, false
+}
+
+// ContrastText picks black or white text, whichever is more readable on the background.
+// Backgrounds that can't be parsed are assumed to be dark.
+func ContrastText(bg Color) Color {
+	r, g, b, ok := colorRGB(bg)
+	// backgrounds brighter than this contrast more with black than with white
+	if ok && luminance(r, g, b) > 0.179 {
+		return Black
+	}
+	return BrightWhite
+}
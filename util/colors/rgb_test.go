@@ -0,0 +1,124 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRGB(t *testing.T) {
+	withLevel(t, LevelTrueColor)
+	if color := RGB(255, 170, 0); color != "\033[38;2;255;170;0m" {
+		t.Errorf("unexpected truecolor foreground %q", color)
+	}
+	if color := BgRGB(1, 2, 3); color != "\033[48;2;1;2;3m" {
+		t.Errorf("unexpected truecolor background %q", color)
+	}
+
+	withLevel(t, Level256)
+	if color := RGB(255, 170, 0); color != "\033[38;5;214m" {
+		t.Errorf("unexpected 256-color foreground %q", color)
+	}
+	if color := BgRGB(0, 255, 135); color != "\033[48;5;48m" {
+		t.Errorf("unexpected 256-color background %q", color)
+	}
+	if color := RGB(128, 128, 128); color != "\033[38;5;244m" {
+		t.Errorf("unexpected 256-color gray %q", color)
+	}
+}
+
+func TestColor256(t *testing.T) {
+	if color := Color256(161); color != "\033[38;5;161m" {
+		t.Errorf("unexpected 256-color sequence %q", color)
+	}
+	for n := 16; n < 256; n++ {
+		r, g, b := rgb256(uint8(n))
+		if nearest := nearest256(r, g, b); nearest != uint8(n) {
+			t.Errorf("palette color %d maps to %d", n, nearest)
+		}
+	}
+}
+
+func TestContrastText(t *testing.T) {
+	black, white := Color("\033[30m"), Color("\033[97m")
+	cases := []struct {
+		name string
+		bg   Color
+		text Color
+	}{
+		{"white", "\033[48;2;255;255;255m", black},
+		{"black", "\033[48;2;0;0;0m", white},
+		{"brand orange", "\033[48;2;255;170;0m", black},
+		{"navy", "\033[48;2;0;0;128m", white},
+		{"light gray", "\033[48;5;252m", black},
+		{"dark gray", "\033[48;5;236m", white},
+		{"red", BgRed, white},
+		{"blue", BgBlue, white},
+		{"yellow", BgYellow, black},
+		{"mint", BgMint, black},
+		{"maroon", BgMaroon, white},
+		{"lavender", BgLavender, black},
+		{"grey", BgGrey, black},
+		{"unknown", "", white},
+	}
+	for _, test := range cases {
+		if text := ContrastText(test.bg); text != test.text {
+			t.Errorf("ContrastText picked %q for a %v background", text, test.name)
+		}
+	}
+}
+
+func TestGradient(t *testing.T) {
+	withLevel(t, LevelTrueColor)
+	from, to := RGB(255, 0, 0), RGB(0, 0, 255)
+
+	expected := "\033[38;2;255;0;0mA\033[38;2;128;0;128mB\033[38;2;0;0;255mC" + string(Clear)
+	if gradient := Gradient("ABC", from, to); gradient != expected {
+		t.Errorf("unexpected gradient %q", gradient)
+	}
+	long := Gradient("Arbitrum Nitro", from, to)
+	if !strings.HasPrefix(long, string(from)+"A") || !strings.HasSuffix(long, string(to)+"o"+string(Clear)) {
+		t.Errorf("gradient doesn't start and end with the endpoints: %q", long)
+	}
+	if StripColor(long) != "Arbitrum Nitro" {
+		t.Errorf("gradient changed the text: %q", StripColor(long))
+	}
+
+	if gradient := Gradient("", from, to); gradient != "" {
+		t.Errorf("unexpected gradient of nothing %q", gradient)
+	}
+	if gradient := Gradient("λ", from, to); gradient != from.Wrap("λ") {
+		t.Errorf("unexpected gradient of one rune %q", gradient)
+	}
+
+	withLevel(t, Level256)
+	if gradient := Gradient("ABC", from, to); gradient != from.Wrap("ABC") {
+		t.Errorf("expected the first color without truecolor, got %q", gradient)
+	}
+}
+
+func TestMix(t *testing.T) {
+	black, white := Color("\033[38;2;0;0;0m"), Color256(231)
+	cases := []struct {
+		a, b  Color
+		t     float64
+		mixed Color
+	}{
+		{black, white, 0, black},
+		{black, white, 1, white},
+		{black, white, -3, black},
+		{black, white, 7, white},
+		{black, white, 0.5, "\033[38;2;128;128;128m"},
+		{Red, Blue, 0.5, "\033[38;2;103;0;119m"},
+		{Color256(196), "\033[38;2;0;0;255m", 0.25, "\033[38;2;191;0;64m"},
+		{Bold, white, 0.25, Bold},
+		{Bold, white, 0.75, white},
+	}
+	for _, test := range cases {
+		if mixed := Mix(test.a, test.b, test.t); mixed != test.mixed {
+			t.Errorf("Mix(%q, %q, %v) = %q, expected %q", test.a, test.b, test.t, mixed, test.mixed)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var Bold Color = "\033[1m"
+var Dim Color = "\033[2m"
+var Italic Color = "\033[3m"
+var Underline Color = "\033[4m"
+var Blink Color = "\033[5m"
+
+// Style merges colors and styles into a single escape sequence, so Style(Underline, Red) yields
+// "\033[4;31;1m" rather than two consecutive sequences. A repeated parameter appears once, where
+// it was last given, since later parameters override earlier ones.
+// Clear, which Wrap appends, resets every attribute the merged sequence sets.
+func Style(codes ...Color) Color {
+	var params []string
+	for _, code := range codes {
+		params = append(params, sgrParams(string(code))...)
+	}
+	var merged []string
+	seen := make(map[string]bool)
+	for i := len(params) - 1; i >= 0; i-- {
+		if !seen[params[i]] {
+			seen[params[i]] = true
+			merged = append(merged, params[i])
+		}
+	}
+	slices.Reverse(merged)
+	return sgr(merged...)
+}
+
+// On combines a foreground color with a background into a single escape sequence.
+func (c Color) On(bg Color) Color {
+	return Style(c, bg)
+}
+
+// UnboldFaint turns off bold and faint text without resetting colors or other styles.
+var UnboldFaint Color = "\033[22m"
+
+// Faint renders the text dimmed, then turns off only the dimming so any color or style around
+// it carries on. Note that the terminal also turns off bold text at the end, since the two
+// share a reset.
+func Faint(s string) string {
+	if !Enabled() {
+		return s
+	}
+	return string(Dim) + s + string(UnboldFaint)
+}
+
+// Reset gives the shortest escape sequence turning off just what the given colors and styles
+// set, so Reset(Underline, BgRed) yields "\033[24;49m" and leaves bold text and foregrounds alone.
+// A code that includes Clear resets everything.
+func Reset(codes ...Color) Color {
+	var resets []string
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		for _, param := range sgrParams(string(code)) {
+			if reset := resetParam(param); reset != "" && !seen[reset] {
+				seen[reset] = true
+				resets = append(resets, reset)
+			}
+		}
+	}
+	if seen["0"] {
+		return sgr("0")
+	}
+	return sgr(resets...)
+}
+
+// resetParam gives the SGR parameter turning off what the given one sets, or "" if there's none.
+func resetParam(param string) string {
+	code, err := strconv.Atoi(strings.SplitN(param, ";", 2)[0])
+	if err != nil {
+		return ""
+	}
+	switch {
+	case code == 0:
+		return "0"
+	case code == 1 || code == 2:
+		return "22"
+	case code == 6:
+		return "25" // rapid blinking, turned off along with slow blinking
+	case code == 21:
+		return "24" // double underlines, turned off along with single ones
+	case code >= 3 && code <= 9:
+		return strconv.Itoa(code + 20)
+	case code >= 30 && code <= 38, code >= 90 && code <= 97:
+		return "39"
+	case code >= 40 && code <= 48, code >= 100 && code <= 107:
+		return "49"
+	case code == 58:
+		return "59"
+	}
+	return ""
+}
+
+// sgr builds the escape sequence that sets the given parameters.
+func sgr(params ...string) Color {
+	if len(params) == 0 {
+		return ""
+	}
+	return Color("\033[" + strings.Join(params, ";") + "m")
+}
+
+var sgrSequence = regexp.MustCompile(`\x1b\[([0-9;:]*)m`)
+
+// sgrParams splits the SGR sequences in the text into their parameters, keeping extended
+// colors like 38;5;N and 38;2;R;G;B together as a single parameter. An empty parameter means 0,
+// as in \033[m, so it's given as "0". Empty fields within an extended color, like the color
+// space in 38:2::R:G:B, are kept as is.
+func sgrParams(text string) []string {
+	var params []string
+	for _, match := range sgrSequence.FindAllStringSubmatch(text, -1) {
+		parts := strings.Split(match[1], ";")
+		for i := 0; i < len(parts); i++ {
+			if parts[i] == "" {
+				parts[i] = "0"
+			}
+			part := parts[i]
+			width := 1
+			if (part == "38" || part == "48" || part == "58") && i+1 < len(parts) {
+				switch parts[i+1] {
+				case "5":
+					width = 3
+				case "2":
+					width = 5
+				}
+			}
+			if i+width > len(parts) {
+				width = len(parts) - i
+			}
+			params = append(params, strings.Join(parts[i:i+width], ";"))
+			i += width - 1
+		}
+	}
+	return params
+}
+
+// Optimize shortens the text's escape sequences without changing how it renders. Within each
+// run of SGR sequences with no text between them, anything before a reset is dropped, since the
+// reset undoes it, and repeats of the same sequence are collapsed into one.
+func Optimize(text string) string {
+	var builder strings.Builder
+	var run []string
+	flush := func() {
+		start := 0
+		for i, sequence := range run {
+			if slices.Contains(sgrParams(sequence), "0") {
+				start = i
+			}
+		}
+		for i, sequence := range run[start:] {
+			if i == 0 || sequence != run[start+i-1] {
+				builder.WriteString(sequence)
+			}
+		}
+		run = run[:0]
+	}
+
+	for i := 0; i < len(text); {
+		if length, _ := escapeLength(text[i:]); length > 0 {
+			sequence := text[i : i+length]
+			if isSGR(sequence) {
+				run = append(run, sequence)
+			} else {
+				flush()
+				builder.WriteString(sequence)
+			}
+			i += length
+			continue
+		}
+		flush()
+		end := strings.IndexByte(text[i+1:], 0x1b)
+		if end < 0 {
+			end = len(text) - i - 1
+		}
+		builder.WriteString(text[i : i+1+end])
+		i += 1 + end
+	}
+	flush()
+	return builder.String()
+}
+
+// Balanced reports whether the text leaves the terminal's styling as it found it, with every
+// color and style it sets reset by the end. Only the final state matters, so a reset followed
+// by a new color that's never reset is unbalanced.
+func Balanced(text string) bool {
+	var state sgrState
+	for i := 0; i < len(text); i++ {
+		if length, _ := escapeLength(text[i:]); length > 0 {
+			state.apply(text[i : i+length])
+			i += length - 1
+		}
+	}
+	return !state.active()
+}
+
+// isSGR reports whether the escape sequence sets graphic rendition, like colors and styles.
+func isSGR(sequence string) bool {
+	return len(sgrSequence.FindString(sequence)) == len(sequence)
+}
+
+// sgrState tracks the rendition established by a run of SGR sequences.
+type sgrState struct {
+	attributes [22]bool // indexed by the SGR parameter that sets each, like 1 for bold or 21 for double underlines
+	fg, bg     string   // the parameter setting each color, like "31" or "38;5;48"
+}
+
+// apply updates the state with the parameters of the escape sequence, ignoring anything
+// that isn't an SGR sequence.
+func (s *sgrState) apply(sequence string) {
+	for _, param := range sgrParams(sequence) {
+		code, err := strconv.Atoi(strings.SplitN(param, ";", 2)[0])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			*s = sgrState{}
+		case code < 10 || code == 21:
+			s.attributes[code] = true
+		case code == 22:
+			s.attributes[1], s.attributes[2] = false, false
+		case code == 24:
+			s.attributes[4], s.attributes[21] = false, false // single and double underlines
+		case code == 25:
+			s.attributes[5], s.attributes[6] = false, false // slow and rapid blinking
+		case code >= 23 && code <= 29:
+			s.attributes[code-20] = false
+		case code >= 30 && code <= 38, code >= 90 && code <= 97:
+			s.fg = param
+		case code == 39:
+			s.fg = ""
+		case code >= 40 && code <= 48, code >= 100 && code <= 107:
+			s.bg = param
+		case code == 49:
+			s.bg = ""
+		}
+	}
+}
+
+// active reports whether any attribute or color differs from the terminal's default.
+func (s *sgrState) active() bool {
+	return s.sequence() != ""
+}
+
+// sequence gives a single escape sequence that establishes the state from the default.
+func (s *sgrState) sequence() Color {
+	var params []string
+	for code, set := range s.attributes {
+		if set {
+			params = append(params, strconv.Itoa(code))
+		}
+	}
+	if s.fg != "" {
+		params = append(params, s.fg)
+	}
+	if s.bg != "" {
+		params = append(params, s.bg)
+	}
+	return sgr(params...)
+}
@@ -0,0 +1,235 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestStyle(t *testing.T) {
+	cases := []struct {
+		codes    []Color
+		expected Color
+	}{
+		{[]Color{"\033[1m", "\033[31;1m"}, "\033[31;1m"},
+		{[]Color{"\033[1m", "\033[4m", "\033[38;5;48;1m"}, "\033[4;38;5;48;1m"},
+		{[]Color{Red, Blue, Red}, "\033[34;31;1m"},
+		{[]Color{Bold, Clear, Bold}, "\033[0;1m"},
+		{[]Color{"\033[3m", "\033[38;2;255;170;0m", "\033[2m"}, "\033[3;38;2;255;170;0;2m"},
+		{[]Color{"\033[0;0m"}, "\033[0m"},
+		{nil, ""},
+	}
+	for _, test := range cases {
+		if style := Style(test.codes...); style != test.expected {
+			t.Errorf("Style(%q) = %q, expected %q", test.codes, style, test.expected)
+		}
+	}
+}
+
+func TestOn(t *testing.T) {
+	var red, bgBlue, bgMint Color = "\033[31;1m", "\033[44m", "\033[48;5;48m"
+	if color := red.On(bgBlue); color != "\033[31;1;44m" {
+		t.Errorf("unexpected combined color %q", color)
+	}
+	if color := Color256(16).On(bgMint); color != "\033[38;5;16;48;5;48m" {
+		t.Errorf("unexpected combined color %q", color)
+	}
+	if text := Uncolor(red.On(bgMint).Wrap("fail") + string(bgBlue) + "ed"); text != "failed" {
+		t.Errorf("backgrounds weren't stripped: %q", text)
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	red, blue, bold, reset := "\033[31;1m", "\033[34;1m", "\033[1m", "\033[0;0m"
+	cursor := "\033[2;3H"
+	cases := []struct {
+		text      string
+		optimized string
+	}{
+		{"plain", "plain"},
+		{red + "a" + reset + reset, red + "a" + reset},
+		{red + "a" + reset + reset + reset + "b", red + "a" + reset + "b"},
+		{red + "a" + reset + blue + "b" + reset, red + "a" + reset + blue + "b" + reset},
+		{red + red + "a" + reset, red + "a" + reset},
+		{red + blue + red + "a", red + blue + red + "a"},
+		{bold + red + reset + "a", reset + "a"},
+		{red + "a" + blue + "\033[0;32m" + "b", red + "a" + "\033[0;32m" + "b"},
+		{red + reset + cursor + reset + "a", reset + cursor + reset + "a"},
+		{"a" + reset + "\033[m" + reset, "a" + reset},
+	}
+	for _, test := range cases {
+		optimized := Optimize(test.text)
+		if optimized != test.optimized {
+			t.Errorf("Optimize(%q) = %q, expected %q", test.text, optimized, test.optimized)
+		}
+		if StripColor(optimized) != StripColor(test.text) {
+			t.Errorf("Optimize(%q) changed the visible text", test.text)
+		}
+	}
+}
+
+func TestFaint(t *testing.T) {
+	text := Red.Wrap("error " + Faint("(retrying)") + " again")
+	if text != Red.Wrap("error \033[2m(retrying)\033[22m again") {
+		t.Errorf("unexpected faint text %q", text)
+	}
+
+	// only the dimming ends with the faint text
+	var state sgrState
+	for _, sequence := range []string{string(Red), string(Underline), string(Dim), string(UnboldFaint)} {
+		state.apply(sequence)
+	}
+	expected := sgrState{fg: "31"}
+	expected.attributes[4] = true
+	if state != expected {
+		t.Errorf("unexpected state %v", state.sequence())
+	}
+
+	Disable()
+	defer Enable()
+	if Faint("quiet") != "quiet" {
+		t.Error("disabled colors kept")
+	}
+}
+
+func TestReset(t *testing.T) {
+	cases := []struct {
+		codes []Color
+		reset Color
+	}{
+		{nil, ""},
+		{[]Color{Bold}, "\033[22m"},
+		{[]Color{Dim, Bold}, "\033[22m"},
+		{[]Color{Underline, BgRed}, "\033[24;49m"},
+		{[]Color{Underline, Red}, "\033[24;39;22m"},
+		{[]Color{Italic, Blink, BgMint}, "\033[23;25;49m"},
+		{[]Color{Pink}, "\033[39;22m"},
+		{[]Color{Style(Italic, Red).On(BgBlue)}, "\033[23;39;22;49m"},
+		{[]Color{Red, Clear}, "\033[0m"},
+	}
+	for _, test := range cases {
+		if reset := Reset(test.codes...); reset != test.reset {
+			t.Errorf("Reset(%q) = %q, expected %q", test.codes, reset, test.reset)
+		}
+	}
+
+	// each reset turns off what it targets and nothing else
+	for _, code := range []Color{Bold, Dim, Italic, Underline, Blink, Red, Pink, BgMint, Color256(200)} {
+		var state sgrState
+		state.apply(string(Style(Underline, Italic, Grey, BgBlue)))
+		before := state
+		state.apply(string(code))
+		state.apply(string(Reset(code)))
+		diff := before
+		diff.apply(string(Reset(code)))
+		if state != diff {
+			t.Errorf("resetting %q left %q, expected %q", code, state.sequence(), diff.sequence())
+		}
+	}
+}
+
+func TestBalanced(t *testing.T) {
+	balanced := []string{
+		"",
+		"plain text",
+		Red.Wrap("error"),
+		Style(Bold, Red).On(BgBlue).Wrap("alert"),
+		Red.Wrap("a") + Blue.Wrap("b"),
+		Red.Wrap("error " + Faint("(retrying)")),
+		string(Underline) + "link" + string(Reset(Underline)),
+		string(Pink) + "pink" + string(Reset(Pink)),
+		string(Red) + "a" + string(Clear) + string(Blue) + "b\033[m",
+		Hyperlink("https://example.com", "docs") + "\033[2K",
+		Wrap(Red.Wrap("the quick brown fox"), 6),
+	}
+	for _, text := range balanced {
+		if !Balanced(text) {
+			t.Errorf("%q should be balanced", text)
+		}
+	}
+
+	unbalanced := []string{
+		string(Red) + "error",
+		string(Red) + "a" + string(Clear) + string(Blue) + "b",
+		string(Style(Bold, Red)) + "a" + string(Reset(BgRed)),
+		string(Underline) + "a" + string(UnboldFaint),
+		string(BgMint) + "a\033[39m",
+		TruncateVisible(Red.Wrap("error"), 3)[:8],
+	}
+	for _, text := range unbalanced {
+		if Balanced(text) {
+			t.Errorf("%q should be unbalanced", text)
+		}
+	}
+}
+
+func TestResetParams(t *testing.T) {
+	everything := "\033[1;2;3;4;5;6;7;8;9;21;31;44m"
+	cases := []struct {
+		reset   string
+		cleared []int
+	}{
+		{"22", []int{1, 2}},
+		{"23", []int{3}},
+		{"24", []int{4, 21}},
+		{"25", []int{5, 6}},
+		{"27", []int{7}},
+		{"28", []int{8}},
+		{"29", []int{9}},
+	}
+	for _, test := range cases {
+		var state sgrState
+		state.apply(everything)
+		state.apply("\033[" + test.reset + "m")
+		var expected sgrState
+		expected.apply(everything)
+		for _, code := range test.cleared {
+			expected.attributes[code] = false
+		}
+		if state != expected {
+			t.Errorf("%v left %q, expected %q", test.reset, state.sequence(), expected.sequence())
+		}
+	}
+
+	// every attribute is turned off by the reset Reset gives for it
+	for _, code := range []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "21", "31", "38;5;48", "44", "48;2;1;2;3"} {
+		set := Color("\033[" + code + "m")
+		var state sgrState
+		state.apply(string(set))
+		state.apply(string(Reset(set)))
+		if state.active() {
+			t.Errorf("Reset(%q) = %q left %q active", set, Reset(set), state.sequence())
+		}
+		if text := string(set) + "a" + string(Reset(set)); !Balanced(text) {
+			t.Errorf("%q should be balanced", text)
+		}
+	}
+}
+
+func TestSGRParams(t *testing.T) {
+	cases := []struct {
+		text   string
+		params []string
+	}{
+		{"\033[m", []string{"0"}},
+		{"\033[;1m", []string{"0", "1"}},
+		{"\033[1;;4m", []string{"1", "0", "4"}},
+		{"\033[38:2::1:2:3;1m", []string{"38:2::1:2:3", "1"}},
+		{"\033[48;2;;1;2;3m", []string{"48;2;;1;2", "3"}},
+		{"\033[38;5;;1m", []string{"38;5;", "1"}},
+	}
+	for _, test := range cases {
+		if params := sgrParams(test.text); !slices.Equal(params, test.params) {
+			t.Errorf("sgrParams(%q) = %q, expected %q", test.text, params, test.params)
+		}
+	}
+
+	if style := Style("\033[38:2::1:2:3m", Bold); style != "\033[38:2::1:2:3;1m" {
+		t.Errorf("Style changed a colon-form color: %q", style)
+	}
+	if style := Style("\033[m", Bold); style != "\033[0;1m" {
+		t.Errorf("Style didn't read an empty parameter as a reset: %q", style)
+	}
+}
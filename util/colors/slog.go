@@ -0,0 +1,136 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+)
+
+// slogHandler colors the output of a slog.TextHandler, which renders each record into buffer.
+type slogHandler struct {
+	inner    slog.Handler
+	buffer   *bytes.Buffer
+	mutex    *sync.Mutex // guards buffer and writes to the writer
+	writer   io.Writer
+	terminal bool // whether the writer is a file that's a terminal
+}
+
+// NewSlogHandler makes a handler that formats records like slog.NewTextHandler, coloring the
+// level and the attribute keys with the colors of the current theme. Values stay plain so they
+// remain greppable. Colors are skipped when disabled, and unless colors are forced, when writing
+// to anything but a terminal, including writers that aren't files.
+func NewSlogHandler(w io.Writer, opts *slog.HandlerOptions) slog.Handler {
+	buffer := new(bytes.Buffer)
+	terminal := false
+	if file, ok := w.(*os.File); ok {
+		terminal = isTerminal(file)
+	}
+	return &slogHandler{
+		inner:    slog.NewTextHandler(buffer, opts),
+		buffer:   buffer,
+		mutex:    new(sync.Mutex),
+		writer:   w,
+		terminal: terminal,
+	}
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.inner.Enabled(ctx, level)
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.buffer.Reset()
+	if err := h.inner.Handle(ctx, record); err != nil {
+		return err
+	}
+	line := h.buffer.Bytes()
+	if Enabled() && (h.terminal || forceColor.Load()) {
+		line = colorRecord(line, levelColor(record.Level))
+	}
+	_, err := h.writer.Write(line)
+	return err
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.inner = h.inner.WithAttrs(attrs)
+	return &handler
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	handler := *h
+	handler.inner = h.inner.WithGroup(name)
+	return &handler
+}
+
+// levelColor picks the theme's color for a level's name.
+func levelColor(level slog.Level) Color {
+	t := CurrentTheme()
+	switch {
+	case level >= slog.LevelError:
+		return t.Error
+	case level >= slog.LevelWarn:
+		return t.Warn
+	case level >= slog.LevelInfo:
+		return t.Info
+	case level >= slog.LevelDebug:
+		return t.Debug
+	default:
+		return t.Trace
+	}
+}
+
+// colorRecord colors the keys of a line of key=value pairs produced by a slog.TextHandler,
+// along with the value of the level.
+func colorRecord(line []byte, level Color) []byte {
+	key := CurrentTheme().Key
+	colored := make([]byte, 0, 2*len(line))
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\n' {
+			colored = append(colored, line[i])
+			i++
+			continue
+		}
+		end := tokenEnd(line, i)
+		if end == len(line) || line[end] != '=' {
+			colored = append(colored, line[i:end]...)
+			i = end
+			continue
+		}
+		name := string(line[i:end])
+		valueEnd := tokenEnd(line, end+1)
+		value := string(line[end+1 : valueEnd])
+		if name == slog.LevelKey {
+			value = level.Wrap(value)
+		}
+		colored = append(colored, key.Wrap(name)+"="+value...)
+		i = valueEnd
+	}
+	return colored
+}
+
+// tokenEnd finds where the key or value starting at the index ends, skipping over quoted text.
+func tokenEnd(line []byte, start int) int {
+	i := start
+	if i < len(line) && line[i] == '"' {
+		for i++; i < len(line) && line[i] != '"'; i++ {
+			if line[i] == '\\' {
+				i++
+			}
+		}
+		return min(i+1, len(line))
+	}
+	for i < len(line) && line[i] != ' ' && line[i] != '\n' && line[i] != '=' {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,52 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	defer Enable()
+	withoutTime := func(groups []string, attr slog.Attr) slog.Attr {
+		if attr.Key == slog.TimeKey && len(groups) == 0 {
+			return slog.Attr{}
+		}
+		return attr
+	}
+	var buffer bytes.Buffer
+	logger := slog.New(NewSlogHandler(&buffer, &slog.HandlerOptions{ReplaceAttr: withoutTime}))
+
+	logger.Info("plain")
+	if buffer.String() != "level=INFO msg=plain\n" {
+		t.Fatalf("expected a plain record for a writer that isn't a terminal, got %q", buffer.String())
+	}
+
+	ForceColor(true)
+	defer ForceColor(false)
+	buffer.Reset()
+	logger.Error("batch failed", "count", 3, "reason", "gas price = too high")
+	key := Grey.Wrap
+	expected := key("level") + "=" + Red.Wrap("ERROR") + " " + key("msg") + `="batch failed" ` +
+		key("count") + "=3 " + key("reason") + `="gas price = too high"` + "\n"
+	if buffer.String() != expected {
+		t.Fatalf("unexpected colored record\n%q\nexpected\n%q", buffer.String(), expected)
+	}
+
+	buffer.Reset()
+	logger.WithGroup("node").Warn("slow", "lag", 2)
+	expected = key("level") + "=" + Yellow.Wrap("WARN") + " " + key("msg") + "=slow " + key("node.lag") + "=2\n"
+	if buffer.String() != expected {
+		t.Fatalf("unexpected grouped record\n%q\nexpected\n%q", buffer.String(), expected)
+	}
+
+	Disable()
+	buffer.Reset()
+	logger.With("chain", 42161).Info("ready")
+	if buffer.String() != "level=INFO msg=ready chain=42161\n" {
+		t.Fatalf("expected a plain record when disabled, got %q", buffer.String())
+	}
+}
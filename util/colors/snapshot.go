@@ -0,0 +1,83 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// generation counts the changes to the palette, theme, and enabled and forced states.
+var generation atomic.Uint64
+
+// snapshot caches the resolved palette along with the generation it was resolved in.
+var snapshot atomic.Pointer[resolvedPalette]
+
+type resolvedPalette struct {
+	generation uint64
+	palette    Palette
+}
+
+var (
+	listenersMutex sync.Mutex
+	listeners      []func()
+)
+
+// Snapshot gives the current palette as it should be printed right now: each color downsampled
+// to what the terminal supports, or None for all of them when colors are disabled. High-volume
+// formatters can hold on to it for a burst of output rather than consulting the palette, the
+// enabled state, and the color level on every line, calling OnChange to learn when to refresh.
+// Snapshots are cached, so taking one never locks.
+//
+// Like the strings Sprint returns, a snapshot doesn't depend on whether stdout is a terminal or
+// colors are forced, since that depends on where the output goes. Formatters writing to a file
+// that isn't a terminal should check that themselves, as the print helpers do.
+func Snapshot() Palette {
+	current := generation.Load()
+	if cached := snapshot.Load(); cached != nil && cached.generation == current {
+		return cached.palette
+	}
+
+	var resolved Palette
+	if Enabled() {
+		p := CurrentPalette()
+		resolved = Palette{
+			Red:      Best(p.Red),
+			Blue:     Best(p.Blue),
+			Yellow:   Best(p.Yellow),
+			Pink:     Best(p.Pink),
+			Mint:     Best(p.Mint),
+			Grey:     Best(p.Grey),
+			Lime:     Best(p.Lime),
+			Lavender: Best(p.Lavender),
+			Maroon:   Best(p.Maroon),
+			Orange:   Best(p.Orange),
+			Clear:    Best(p.Clear),
+		}
+	}
+	// a change made while resolving bumps the generation, so this entry is never used
+	snapshot.Store(&resolvedPalette{current, resolved})
+	return resolved
+}
+
+// OnChange registers a function to call whenever the palette, the theme, or whether colors are
+// enabled or forced changes. It's called on the goroutine making the change, after the
+// change takes effect, so it should be quick, like dropping a cached Snapshot.
+func OnChange(f func()) {
+	listenersMutex.Lock()
+	defer listenersMutex.Unlock()
+	listeners = append(listeners, f)
+}
+
+// changed invalidates snapshots and notifies the functions registered with OnChange.
+func changed() {
+	generation.Add(1)
+	listenersMutex.Lock()
+	notify := slices.Clone(listeners)
+	listenersMutex.Unlock()
+	for _, f := range notify {
+		f()
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"io"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	var changes atomic.Int32
+	OnChange(func() { changes.Add(1) })
+
+	if Snapshot() != CurrentPalette() {
+		t.Errorf("unexpected snapshot %q", Snapshot())
+	}
+	withLevel(t, LevelBasic)
+	if red, mint := Snapshot().Red, Snapshot().Mint; red != Red || mint != "\033[36;1m" {
+		t.Errorf("unexpected downsampled snapshot %q %q", red, mint)
+	}
+
+	custom := DefaultPalette()
+	custom.Red = Color256(160)
+	SetPalette(custom)
+	defer SetPalette(DefaultPalette())
+	if red := Snapshot().Red; red != "\033[31m" {
+		t.Errorf("snapshot missed the new palette: %q", red)
+	}
+
+	Disable()
+	if Snapshot() != (Palette{}) {
+		t.Errorf("disabled snapshot has colors %q", Snapshot())
+	}
+	Disable()
+	Enable()
+	if Snapshot().Red != "\033[31m" {
+		t.Errorf("snapshot missed colors being enabled")
+	}
+
+	SetTheme(DefaultTheme())
+	ForceColor(true)
+	forced := Snapshot()
+	ForceColor(false)
+	if Snapshot() != forced {
+		t.Errorf("forcing colors changed the snapshot")
+	}
+
+	// two level changes, the palette change, disabling and enabling once each, the theme, and forcing twice
+	if count := changes.Load(); count != 7 {
+		t.Errorf("saw %v changes", count)
+	}
+}
+
+func TestSnapshotForced(t *testing.T) {
+	withLevel(t, LevelNone)
+	ForceColor(true)
+	defer ForceColor(false)
+	output := captureStdout(t, func() { PrintMint("x") })
+	if snapshot := Snapshot().Mint.Wrap("x") + "\n"; snapshot != output {
+		t.Errorf("forced at LevelNone, the snapshot gave %q while PrintMint printed %q", snapshot, output)
+	}
+}
+
+// BenchmarkLineWithAccessors formats a log line the way a formatter consulting the palette, the
+// enabled state, and the color level for every line would.
+func BenchmarkLineWithAccessors(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = io.WriteString(io.Discard, Best(CurrentPalette().Mint).Wrap("INFO")+" "+Best(CurrentPalette().Grey).Wrap("block")+"=42\n")
+	}
+}
+
+func BenchmarkLineWithSnapshot(b *testing.B) {
+	palette := Snapshot()
+	for i := 0; i < b.N; i++ {
+		_, _ = io.WriteString(io.Discard, string(palette.Mint)+"INFO"+string(palette.Clear)+" "+string(palette.Grey)+"block"+string(palette.Clear)+"=42\n")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are drawn in turn, one per tick.
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Spinner animates a label while an operation of unknown length runs. When the output isn't
+// a terminal the label is printed once instead.
+type Spinner struct {
+	mutex    sync.Mutex // guards everything below and serializes Start and Stop
+	label    string
+	writer   io.Writer
+	terminal bool
+	interval time.Duration
+	stop     chan struct{} // closed to end the animation
+	done     chan struct{} // closed once the animation has ended
+}
+
+// NewSpinner makes a spinner for the label that writes to stdout.
+func NewSpinner(label string) *Spinner {
+	return &Spinner{
+		label:    label,
+		writer:   os.Stdout,
+		terminal: terminal,
+		interval: 100 * time.Millisecond,
+	}
+}
+
+// SetOutput directs the spinner to the writer, which is only animated if it's a terminal.
+// It takes effect on the next call to Start.
+func (s *Spinner) SetOutput(w io.Writer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.writer = w
+	s.terminal = false
+	if file, ok := w.(*os.File); ok {
+		s.terminal = isTerminal(file)
+	}
+}
+
+// Start begins the animation, doing nothing if the spinner is already running.
+func (s *Spinner) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	if !s.terminal {
+		fmt.Fprintln(s.writer, s.label)
+		return
+	}
+	s.done = make(chan struct{})
+	go spin(s.writer, s.label, s.interval, s.stop, s.done)
+}
+
+// Stop ends the animation, waiting for it to finish before clearing the line.
+func (s *Spinner) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	if s.done != nil {
+		<-s.done
+		fmt.Fprint(s.writer, "\r\033[K")
+	}
+	s.stop, s.done = nil, nil
+}
+
+// spin draws a frame each tick until stop is closed. It receives its state as arguments so
+// it never touches the spinner, which may be reconfigured while it runs.
+func spin(w io.Writer, label string, interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for frame := 0; ; frame++ {
+		glyph := spinnerFrames[frame%len(spinnerFrames)]
+		if Enabled() {
+			glyph = Downsample(CurrentPalette().Mint).Wrap(glyph)
+		}
+		fmt.Fprintf(w, "\r%s %s", glyph, label)
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpinnerPlain(t *testing.T) {
+	var buffer bytes.Buffer
+	spinner := NewSpinner("syncing")
+	spinner.SetOutput(&buffer)
+	spinner.Start()
+	spinner.Start()
+	spinner.Stop()
+	spinner.Stop()
+	if buffer.String() != "syncing\n" {
+		t.Errorf("unexpected output %q", buffer.String())
+	}
+}
+
+func TestSpinnerTerminal(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	var buffer bytes.Buffer
+	spinner := NewSpinner("syncing")
+	spinner.writer, spinner.terminal = &buffer, true
+	spinner.interval = time.Millisecond
+	for i := 0; i < 100; i++ {
+		spinner.Start()
+		if i%10 == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		spinner.Stop()
+	}
+	spinner.Stop()
+
+	output := buffer.String()
+	if !strings.HasPrefix(output, "\r"+Mint.Wrap(spinnerFrames[0])+" syncing") {
+		t.Errorf("unexpected first frame %q", output)
+	}
+	if !strings.HasSuffix(output, "\r\033[K") || strings.Count(output, "\r\033[K") != 100 {
+		t.Errorf("each stop should clear the line once: %q", output)
+	}
+
+	// stopped spinners must not leave goroutines behind
+	for i := 0; runtime.NumGoroutine() > before && i < 100; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("spinner leaked %v goroutines", after-before)
+	}
+}
+
+func TestSpinnerConcurrent(t *testing.T) {
+	var buffer bytes.Buffer
+	spinner := NewSpinner("waiting")
+	spinner.writer, spinner.terminal = &buffer, true
+	spinner.interval = time.Millisecond
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for j := 0; j < 50; j++ {
+				spinner.Start()
+				spinner.Stop()
+			}
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+	// overlapping calls are no-ops, but every animation that starts must be cleared
+	output := buffer.String()
+	clears := strings.Count(output, "\r\033[K")
+	if clears == 0 || !strings.HasSuffix(output, "\r\033[K") {
+		t.Error("spinner never cleared its line")
+	}
+	if starts := strings.Count(output, "\033[K\r"+Mint.Wrap(spinnerFrames[0])); starts != clears-1 {
+		t.Errorf("%v animations started but %v cleared", starts+1, clears)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"io"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+// TabWriter aligns tab-separated columns like text/tabwriter, but measures cells by their
+// visible width so escape sequences and wide characters don't throw off the alignment.
+//
+// It runs a tabwriter in FilterHTML mode, which treats tags as zero width and entities as one
+// cell wide. Escape sequences and zero-width runes are queued and replaced by "<>", wide runes
+// by "&;&_;", and literal '<' and '&' by "&;". On the way out, each "<>" and "&;" is swapped
+// for the next queued text, and each "&_;" is dropped.
+type TabWriter struct {
+	tabs    *tabwriter.Writer
+	decoder *tabDecoder
+	pending []byte // the start of an escape sequence or rune split across writes
+}
+
+// NewTabWriter makes a writer that pads cells with spaces, with the same meaning of minwidth,
+// tabwidth, and padding as tabwriter.NewWriter. Like a tabwriter, it must be flushed.
+func NewTabWriter(w io.Writer, minwidth, tabwidth, padding int) *TabWriter {
+	decoder := &tabDecoder{writer: w}
+	return &TabWriter{
+		tabs:    tabwriter.NewWriter(decoder, minwidth, tabwidth, padding, ' ', tabwriter.FilterHTML),
+		decoder: decoder,
+	}
+}
+
+func (w *TabWriter) Write(p []byte) (int, error) {
+	data := p
+	if len(w.pending) > 0 {
+		w.pending = append(w.pending, p...)
+		data, w.pending = w.pending, nil
+	}
+
+	encoded := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		switch c := data[0]; {
+		case c == 0x1b:
+			length, partial := escapeLength(data)
+			if partial && len(data) < maxEscapeLength {
+				w.pending = append([]byte{}, data...)
+				data = nil
+				continue
+			}
+			length = max(length, 1)
+			encoded = w.queue(encoded, "<>", data[:length])
+			data = data[length:]
+		case c == '<' || c == '&':
+			encoded = w.queue(encoded, "&;", data[:1])
+			data = data[1:]
+		case c < utf8.RuneSelf:
+			encoded = append(encoded, c)
+			data = data[1:]
+		case !utf8.FullRune(data):
+			w.pending = append([]byte{}, data...)
+			data = nil
+		default:
+			r, size := utf8.DecodeRune(data)
+			switch runeWidth(r) {
+			case 0:
+				encoded = w.queue(encoded, "<>", data[:size])
+			case 2:
+				encoded = append(w.queue(encoded, "&;", data[:size]), "&_;"...)
+			default:
+				encoded = append(encoded, data[:size]...)
+			}
+			data = data[size:]
+		}
+	}
+
+	if _, err := w.tabs.Write(encoded); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// queue appends the placeholder to the encoded text, saving the text it stands for.
+func (w *TabWriter) queue(encoded []byte, placeholder string, text []byte) []byte {
+	w.decoder.queue = append(w.decoder.queue, string(text))
+	return append(encoded, placeholder...)
+}
+
+// Flush writes any buffered cells, including an incomplete escape sequence or rune held back
+// from the last write, which is treated as zero width.
+func (w *TabWriter) Flush() error {
+	if len(w.pending) > 0 {
+		encoded := w.queue(nil, "<>", w.pending)
+		w.pending = nil
+		if _, err := w.tabs.Write(encoded); err != nil {
+			return err
+		}
+	}
+	return w.tabs.Flush()
+}
+
+// tabDecoder restores the text that TabWriter replaced with placeholders.
+type tabDecoder struct {
+	writer  io.Writer
+	queue   []string // the replaced text, in order
+	pending []byte   // the start of a placeholder split across writes
+}
+
+func (d *tabDecoder) Write(p []byte) (int, error) {
+	data := p
+	if len(d.pending) > 0 {
+		d.pending = append(d.pending, p...)
+		data, d.pending = d.pending, nil
+	}
+
+	decoded := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		start := bytes.IndexAny(data, "<&")
+		if start < 0 {
+			decoded = append(decoded, data...)
+			break
+		}
+		decoded = append(decoded, data[:start]...)
+		data = data[start:]
+
+		end := bytes.IndexAny(data, ">;")
+		if end < 0 {
+			d.pending = append([]byte{}, data...)
+			break
+		}
+		if placeholder := string(data[:end+1]); placeholder != "&_;" && len(d.queue) > 0 {
+			decoded = append(decoded, d.queue[0]...)
+			d.queue = d.queue[1:]
+		}
+		data = data[end+1:]
+	}
+
+	if len(decoded) > 0 {
+		if _, err := d.writer.Write(decoded); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
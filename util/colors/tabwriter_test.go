@@ -0,0 +1,63 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"text/tabwriter"
+)
+
+func TestTabWriter(t *testing.T) {
+	var plain bytes.Buffer
+	tabs := tabwriter.NewWriter(&plain, 0, 8, 2, ' ', 0)
+	fmt.Fprint(tabs, "sequencer\trunning\nbatch poster\tstopped\nvalidator\t<syncing> & waiting\n")
+	if err := tabs.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	link := "\033]8;;https://example.com/?a<b>&c;\033\\batch poster\033]8;;\033\\"
+	table := fmt.Sprintf(
+		"%s\t%s\n%s\t%s\n%s\t%s\n",
+		Red.Wrap("sequencer"), "running",
+		link, Mint.Wrap("stopped"),
+		Style(Bold, Blue).Wrap("validator"), "<syncing> & waiting",
+	)
+
+	// the result shouldn't depend on how the table is split across writes
+	for _, size := range []int{len(table), 1, 3} {
+		var colored bytes.Buffer
+		writer := NewTabWriter(&colored, 0, 8, 2)
+		for i := 0; i < len(table); i += size {
+			if _, err := writer.Write([]byte(table[i:min(i+size, len(table))])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		if StripColor(colored.String()) != plain.String() {
+			t.Errorf("misaligned table with writes of %v bytes\n%v\nexpected\n%v", size, StripColor(colored.String()), plain.String())
+		}
+		expected := Red.Wrap("sequencer") + "     running\n" + link + "  " + Mint.Wrap("stopped") + "\n"
+		if !bytes.HasPrefix(colored.Bytes(), []byte(expected)) {
+			t.Errorf("escape sequences not preserved in %q", colored.String())
+		}
+	}
+}
+
+func TestTabWriterWidths(t *testing.T) {
+	var buffer bytes.Buffer
+	writer := NewTabWriter(&buffer, 0, 8, 1)
+	fmt.Fprint(writer, "日本\t|\nété\t|\nabcd\t|\n\033[\t|\n")
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "日本 |\nété  |\nabcd |\n\033[    |\n"
+	if buffer.String() != expected {
+		t.Errorf("unexpected table %q\nexpected %q", buffer.String(), expected)
+	}
+}
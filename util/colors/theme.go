@@ -0,0 +1,53 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// Theme assigns colors to the roles text plays, like the levels of log records. It can be
+// loaded from JSON such as {"error":"red","warn":"#ffaa00"}.
+type Theme struct {
+	Info  Color `json:"info"`
+	Warn  Color `json:"warn"`
+	Error Color `json:"error"`
+	Debug Color `json:"debug"`
+	Trace Color `json:"trace"`
+	Key   Color `json:"key"` // the keys of key/value pairs, like the attributes of log records
+}
+
+// DefaultTheme returns the theme the package starts out with.
+func DefaultTheme() Theme {
+	return Theme{Info: Mint, Warn: Yellow, Error: Red, Debug: Blue, Trace: Grey, Key: Grey}
+}
+
+var theme atomic.Pointer[Theme]
+
+func init() {
+	defaults := DefaultTheme()
+	theme.Store(&defaults)
+}
+
+// CurrentTheme returns the theme in use.
+func CurrentTheme() Theme {
+	return *theme.Load()
+}
+
+// SetTheme changes the theme, which colors the records of slog handlers, including handlers
+// made before the change, and the keys KV and KVList format.
+func SetTheme(t Theme) {
+	theme.Store(&t)
+	changed()
+}
+
+// LoadTheme decodes a theme from JSON. Roles the JSON leaves out keep their default colors.
+func LoadTheme(data []byte) (Theme, error) {
+	loaded := DefaultTheme()
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return Theme{}, err
+	}
+	return loaded, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLoadTheme(t *testing.T) {
+	loaded, err := LoadTheme([]byte(`{"error":"magenta","warn":"#ffaa00"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := DefaultTheme()
+	expected.Error, expected.Warn = Magenta, "\033[38;2;255;170;0m"
+	if loaded != expected {
+		t.Fatalf("unexpected theme %+v", loaded)
+	}
+	if _, err := LoadTheme([]byte(`{"error":"chartreuse"}`)); err == nil {
+		t.Fatal("loading an unknown color should fail")
+	}
+}
+
+func TestSetTheme(t *testing.T) {
+	defer SetTheme(DefaultTheme())
+	ForceColor(true)
+	defer ForceColor(false)
+	var buffer bytes.Buffer
+	logger := slog.New(NewSlogHandler(&buffer, nil))
+
+	logger.Error("failed")
+	if !strings.Contains(buffer.String(), "="+Red.Wrap("ERROR")+" ") {
+		t.Fatalf("expected the default error color, got %q", buffer.String())
+	}
+
+	colorblind := DefaultTheme()
+	colorblind.Error = BrightBlue
+	SetTheme(colorblind)
+	buffer.Reset()
+	logger.Error("failed")
+	if !strings.Contains(buffer.String(), "="+BrightBlue.Wrap("ERROR")+" ") {
+		t.Fatalf("expected the new theme's error color, got %q", buffer.String())
+	}
+}
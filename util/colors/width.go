@@ -0,0 +1,262 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// VisibleWidth measures how many terminal columns the text occupies once its escape sequences
+// are removed. East Asian wide characters take two columns, while combining marks and other
+// invisible characters take none.
+func VisibleWidth(text string) int {
+	width := 0
+	for _, r := range StripColor(text) {
+		width += runeWidth(r)
+	}
+	return width
+}
+
+// TruncateVisible shortens the text to fit within the given number of columns, not counting
+// escape sequences. Escape sequences are never cut in half, and a Clear is appended if the
+// text would otherwise leave a color or style active.
+func TruncateVisible(text string, width int) string {
+	head, _ := SplitVisible(text, width)
+	return head
+}
+
+// SplitVisible splits the text after its first n columns, not counting escape sequences, so
+// the head fits within n columns and the tail holds the rest. A rune that would straddle the
+// split goes to the tail. Escape sequences are never cut in half: those at the split that turn
+// off what the head turned on stay with it, and the rest go with the tail. If a color or style
+// is still active at the split, the head ends with a Clear and the tail starts by setting it
+// again, so each half renders on its own as it did in the whole.
+func SplitVisible(text string, n int) (head, tail string) {
+	var state, current sgrState // as of the split, and as of i
+	visible, split, i := 0, 0, 0
+	for i < len(text) {
+		if length, _ := escapeLength(text[i:]); length > 0 {
+			current.apply(text[i : i+length])
+			i += length
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if visible+runeWidth(r) > n {
+			break
+		}
+		visible += runeWidth(r)
+		i += size
+		split, state = i, current
+	}
+
+	// only escape sequences lie between the last rune of the head and i
+	ending := state
+	for j := split; j < i; {
+		length, _ := escapeLength(text[j:])
+		ending.apply(text[j : j+length])
+		j += length
+		if !ending.active() {
+			split, state = j, ending
+		}
+	}
+
+	head, tail = text[:split], text[split:]
+	if state.active() {
+		head += string(Clear)
+		tail = string(state.sequence()) + tail
+	}
+	return head, tail
+}
+
+// Wrap breaks the text into lines at most width columns wide, not counting escape sequences,
+// breaking at spaces where it can and splitting words too long for a line of their own. Spaces
+// at a break are dropped, as is indentation the first word doesn't fit after. A color or style
+// active at a break is cleared before the newline and set again after it, so it carries on into
+// the next line without bleeding into the margin. Widths below 1 leave the text as is.
+func Wrap(text string, width int) string {
+	if width < 1 {
+		return text
+	}
+	var builder strings.Builder
+	var state sgrState
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			builder.WriteByte('\n')
+		}
+		wrapLine(&builder, &state, line, width)
+	}
+	return builder.String()
+}
+
+// wrapLine writes a line of text without newlines, breaking it to fit within the width.
+func wrapLine(builder *strings.Builder, state *sgrState, line string, width int) {
+	column := 0
+	lineBreak := func() {
+		if state.active() {
+			builder.WriteString(string(Clear))
+		}
+		builder.WriteByte('\n')
+		builder.WriteString(string(state.sequence()))
+		column = 0
+	}
+	// write emits the piece, dropping its visible runes if it's a gap at a break
+	write := func(piece string, visible bool) {
+		for i := 0; i < len(piece); {
+			if length, _ := escapeLength(piece[i:]); length > 0 {
+				state.apply(piece[i : i+length])
+				builder.WriteString(piece[i : i+length])
+				i += length
+				continue
+			}
+			r, size := utf8.DecodeRuneInString(piece[i:])
+			if visible {
+				builder.WriteString(piece[i : i+size])
+				column += runeWidth(r)
+			}
+			i += size
+		}
+	}
+	// place writes the piece, splitting it across lines if it's wider than the space left
+	place := func(piece string) {
+		for VisibleWidth(piece) > 0 && column+VisibleWidth(piece) > width {
+			head, tail := SplitVisible(piece, width-column)
+			if VisibleWidth(head) == 0 {
+				if column > 0 {
+					lineBreak()
+					continue
+				}
+				head, tail = SplitVisible(piece, 2) // a wide rune on a line too narrow for it
+			}
+			write(head, true)
+			if piece = tail; VisibleWidth(piece) > 0 {
+				lineBreak()
+			}
+		}
+		write(piece, true)
+	}
+
+	pieces := wordPieces(line)
+	for i := 0; i < len(pieces); i += 2 {
+		gap, word := pieces[i], ""
+		if i+1 < len(pieces) {
+			word = pieces[i+1]
+		}
+		gapWidth, wordWidth := VisibleWidth(gap), VisibleWidth(word)
+		switch {
+		case column+gapWidth+wordWidth <= width:
+			place(gap) // including any indentation
+		case column == 0:
+			write(gap, false) // indentation that would leave a line of nothing but spaces
+		case word == "":
+			write(gap, false) // trailing spaces that don't fit
+		default:
+			write(gap, false)
+			lineBreak()
+		}
+		if column > 0 && column+wordWidth > width && wordWidth <= width {
+			lineBreak() // the word fits on a line of its own
+		}
+		place(word)
+	}
+}
+
+// wordPieces splits the line into alternating runs of spaces and words, starting with a
+// possibly empty run of spaces. Escape sequences go with the word they precede or follow.
+func wordPieces(line string) []string {
+	pieces := []string{}
+	start, visibleEnd, inWord := 0, 0, false
+	for i := 0; i < len(line); {
+		if length, _ := escapeLength(line[i:]); length > 0 {
+			i += length
+			continue
+		}
+		if isWord := line[i] != ' '; isWord != inWord {
+			boundary := i
+			if isWord {
+				boundary = visibleEnd // the word takes the escape sequences before it
+			}
+			pieces = append(pieces, line[start:boundary])
+			start, inWord = boundary, isWord
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+		visibleEnd = i
+	}
+	return append(pieces, line[start:])
+}
+
+// runeWidth gives the number of columns a terminal uses to display the rune.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf, unicode.Cc):
+		return 0
+	case unicode.Is(wide, r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// wide holds the East Asian wide and fullwidth characters, including emoji presentations.
+var wide = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0x9fff, Stride: 1},
+		{Lo: 0xa000, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18aff, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f0cf, Stride: 203},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
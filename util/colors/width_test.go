@@ -0,0 +1,165 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	cases := []struct {
+		text  string
+		width int
+	}{
+		{"", 0},
+		{"plain", 5},
+		{Red.Wrap("red") + " " + Mint.On(BgGrey).Wrap("mint"), 8},
+		{"e\u0301te\u0301", 3},
+		{"中文", 4},
+		{Blue.Wrap("가") + "!", 3},
+		{"ＡＢ", 4},
+		{"zero\u200bwidth", 9},
+		{"\U0001f680 launch", 9},
+	}
+	for _, test := range cases {
+		if width := VisibleWidth(test.text); width != test.width {
+			t.Errorf("VisibleWidth(%q) = %v, expected %v", test.text, width, test.width)
+		}
+	}
+}
+
+func TestTruncateVisible(t *testing.T) {
+	red, bold, reset := "\033[31;1m", "\033[1m", "\033[0;0m"
+	cases := []struct {
+		text      string
+		width     int
+		truncated string
+	}{
+		{"plain text", 5, "plain"},
+		{"short", 10, "short"},
+		{red + "abc" + reset + "def", 3, red + "abc" + reset},
+		{red + "abc" + reset + "def", 2, red + "ab" + reset},
+		{"abc" + red + "def" + reset, 3, "abc"},
+		{"abc" + red + "def" + reset, 4, "abc" + red + "d" + reset},
+		{red + "ab" + reset + bold + "cd" + reset, 2, red + "ab" + reset},
+		{bold + red + "中文" + reset, 3, bold + red + "中" + reset},
+		{"éé", 1, "é"},
+		{red + "abc", 0, ""},
+	}
+	for _, test := range cases {
+		truncated := TruncateVisible(test.text, test.width)
+		if truncated != test.truncated {
+			t.Errorf("TruncateVisible(%q, %v) = %q, expected %q", test.text, test.width, truncated, test.truncated)
+		}
+		for i := 0; i < len(truncated); i++ {
+			if truncated[i] != 0x1b {
+				continue
+			}
+			length, partial := escapeLength(truncated[i:])
+			if partial || length == 0 {
+				t.Errorf("TruncateVisible(%q, %v) left a partial escape", test.text, test.width)
+			}
+			i += length - 1
+		}
+	}
+}
+
+func TestSplitVisible(t *testing.T) {
+	red, blue, bold, reset := "\033[31;1m", "\033[34;1m", "\033[1m", "\033[0;0m"
+	reopened := "\033[1;31m" // red as set again from the state, which lists attributes first
+	cases := []struct {
+		text       string
+		n          int
+		head, tail string
+	}{
+		{"plain text", 5, "plain", " text"},
+		{"short", 10, "short", ""},
+		{"short", 0, "", "short"},
+		{red + "abcdef" + reset, 2, red + "ab" + reset, reopened + "cdef" + reset},
+		{red + "abc" + reset + "def", 3, red + "abc" + reset, "def"},
+		{"abc" + red + "def" + reset, 3, "abc", red + "def" + reset},
+		{red + "ab" + reset + blue + "cd" + reset, 2, red + "ab" + reset, blue + "cd" + reset},
+		{bold + "ab" + red + "cd" + reset, 3, bold + "ab" + red + "c" + reset, reopened + "d" + reset},
+		{"中文", 3, "中", "文"},
+		{"e\u0301e\u0301", 1, "e\u0301", "e\u0301"},
+		{red + "abc", 0, "", red + "abc"},
+		{red + "abc", 3, red + "abc" + reset, reopened},
+	}
+	for _, test := range cases {
+		head, tail := SplitVisible(test.text, test.n)
+		if head != test.head || tail != test.tail {
+			t.Errorf("SplitVisible(%q, %v) = %q, %q, expected %q, %q", test.text, test.n, head, tail, test.head, test.tail)
+		}
+		if StripColor(head)+StripColor(tail) != StripColor(test.text) {
+			t.Errorf("SplitVisible(%q, %v) lost text", test.text, test.n)
+		}
+		if !Balanced(head) {
+			t.Errorf("SplitVisible(%q, %v) left the head unbalanced", test.text, test.n)
+		}
+	}
+}
+
+func TestWrap(t *testing.T) {
+	cases := []struct {
+		text    string
+		width   int
+		wrapped string
+	}{
+		{"the quick brown fox jumps", 10, "the quick\nbrown fox\njumps"},
+		{"the quick brown fox jumps", 9, "the quick\nbrown fox\njumps"},
+		{"the quick", 100, "the quick"},
+		{"  indented  text", 8, "indented\ntext"},
+		{"  indented  text", 10, "  indented\ntext"},
+		{"      ", 4, ""},
+		{"  indented  text", 12, "  indented\ntext"},
+		{"abcdefghij", 4, "abcd\nefgh\nij"},
+		{"to abcdefghij", 4, "to\nabcd\nefgh\nij"},
+		{"日本語のテキスト", 5, "日本\n語の\nテキ\nスト"},
+		{"日本", 1, "日\n本"},
+		{"first line\nsecond line", 6, "first\nline\nsecond\nline"},
+		{"trailing   ", 8, "trailing"},
+		{"", 5, ""},
+		{"untouched text", 0, "untouched text"},
+	}
+	for _, test := range cases {
+		wrapped := Wrap(test.text, test.width)
+		if wrapped != test.wrapped {
+			t.Errorf("Wrap(%q, %v) = %q, expected %q", test.text, test.width, wrapped, test.wrapped)
+		}
+		if test.width < 1 {
+			continue
+		}
+		for _, line := range strings.Split(wrapped, "\n") {
+			if VisibleWidth(line) > test.width && utf8.RuneCountInString(line) > 1 {
+				t.Errorf("Wrap(%q, %v) has a line %v columns wide", test.text, test.width, VisibleWidth(line))
+			}
+		}
+	}
+}
+
+func TestWrapColors(t *testing.T) {
+	red := "\033[1;31m" // as reasserted from the state, which lists attributes first
+	text := Red.Wrap("the quick brown") + " fox " + Blue.Wrap("jumps")
+	expected := string(Red) + "the quick" + string(Clear) + "\n" + red + "brown" + string(Clear) + " fox\n" + Blue.Wrap("jumps")
+	if wrapped := Wrap(text, 10); wrapped != expected {
+		t.Errorf("unexpected wrapping %q\nexpected %q", wrapped, expected)
+	}
+
+	long := Underline.Wrap("abcdef")
+	expected = string(Underline) + "abc" + string(Clear) + "\n" + string(Underline) + "def" + string(Clear)
+	if wrapped := Wrap(long, 3); wrapped != expected {
+		t.Errorf("unexpected wrapping %q\nexpected %q", wrapped, expected)
+	}
+
+	for _, line := range strings.Split(Wrap(text, 4), "\n") {
+		if VisibleWidth(line) > 4 {
+			t.Errorf("line %q is too wide", line)
+		}
+	}
+	if plain := StripColor(Wrap(text, 10)); plain != "the quick\nbrown fox\njumps" {
+		t.Errorf("unexpected text %q", plain)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"io"
+)
+
+// maxEscapeLength bounds how much of an unterminated escape sequence is held back waiting for
+// the rest. Anything longer is passed through as text.
+const maxEscapeLength = 4096
+
+// escapeLength measures the CSI sequence or OSC string at the start of the text, which should
+// begin with ESC. It returns 0 if the text doesn't start with one, and sets partial if the text
+// ends before the sequence does.
+func escapeLength[T string | []byte](text T) (length int, partial bool) {
+	if len(text) < 2 {
+		return 0, len(text) == 1 && text[0] == 0x1b
+	}
+	if text[0] != 0x1b {
+		return 0, false
+	}
+	switch text[1] {
+	case '[':
+		i := 2
+		for i < len(text) && text[i] >= 0x30 && text[i] <= 0x3f {
+			i++ // parameter bytes
+		}
+		for i < len(text) && text[i] >= 0x20 && text[i] <= 0x2f {
+			i++ // intermediate bytes
+		}
+		if i == len(text) {
+			return 0, true
+		}
+		if text[i] >= 0x40 && text[i] <= 0x7e {
+			return i + 1, false
+		}
+	case ']':
+		for i := 2; i < len(text); i++ {
+			switch text[i] {
+			case 0x07:
+				return i + 1, false
+			case 0x1b:
+				if i+1 == len(text) {
+					return 0, true
+				}
+				if text[i+1] == '\\' {
+					return i + 2, false
+				}
+				return 0, false
+			}
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// StripWriter removes escape sequences from the text written to it before forwarding it.
+type StripWriter struct {
+	writer  io.Writer
+	pending []byte // the start of an escape sequence split across writes
+}
+
+// NewStripWriter makes a writer that removes escape sequences before forwarding text to the
+// given writer. A sequence split across writes is held back until the rest of it arrives, so
+// the writer should be flushed once everything is written.
+func NewStripWriter(writer io.Writer) *StripWriter {
+	return &StripWriter{writer: writer}
+}
+
+// Write strips p and forwards the text. If forwarding fails, the count covers the bytes of p
+// whose text was forwarded, along with any escape sequences before them.
+func (w *StripWriter) Write(p []byte) (int, error) {
+	data := p
+	held := len(w.pending) // the bytes at the start of data that came from an earlier write
+	if held > 0 {
+		w.pending = append(w.pending, p...)
+		data, w.pending = w.pending, nil
+	}
+
+	// each run of text is recorded by where it starts in plain and in data
+	type run struct{ plain, data int }
+	var runs []run
+	plain := make([]byte, 0, len(data))
+	for offset := 0; offset < len(data); {
+		escape := bytes.IndexByte(data[offset:], 0x1b)
+		if escape < 0 {
+			escape = len(data) - offset
+		}
+		if escape > 0 {
+			runs = append(runs, run{len(plain), offset})
+			plain = append(plain, data[offset:offset+escape]...)
+			offset += escape
+			if offset == len(data) {
+				break
+			}
+		}
+
+		length, partial := escapeLength(data[offset:])
+		if partial && len(data)-offset < maxEscapeLength {
+			w.pending = append([]byte{}, data[offset:]...)
+			break
+		}
+		if length == 0 {
+			runs = append(runs, run{len(plain), offset})
+			plain = append(plain, data[offset])
+			length = 1
+		}
+		offset += length
+	}
+
+	if len(plain) > 0 {
+		if n, err := w.writer.Write(plain); err != nil {
+			w.pending = nil
+			consumed := 0
+			for _, run := range runs {
+				if run.plain <= n {
+					consumed = run.data + n - run.plain
+				}
+			}
+			return max(consumed-held, 0), err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush forwards an incomplete escape sequence held back from the last write as is.
+func (w *StripWriter) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	pending := w.pending
+	w.pending = nil
+	_, err := w.writer.Write(pending)
+	return err
+}
+
+// LineColorWriter keeps the colors of each line written to it to that line.
+type LineColorWriter struct {
+	writer  io.Writer
+	state   sgrState // the rendition the text written so far leaves active
+	reopen  bool     // whether a line has started without the active rendition being set again
+	pending []byte   // the start of an escape sequence split across writes
+}
+
+// NewLineColorWriter makes a writer that keeps each line's colors to itself, which pagers like
+// less -R rely on when scrolling. Any color or style still active at a newline is cleared
+// before it and set again once the next line has text, so every line starts with the colors
+// it's shown in. A sequence split across writes is held back until the rest of it arrives, so
+// the writer should be flushed once everything is written.
+func NewLineColorWriter(w io.Writer) *LineColorWriter {
+	return &LineColorWriter{writer: w}
+}
+
+func (w *LineColorWriter) Write(p []byte) (int, error) {
+	data := p
+	if len(w.pending) > 0 {
+		w.pending = append(w.pending, p...)
+		data, w.pending = w.pending, nil
+	}
+
+	output := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		switch data[0] {
+		case 0x1b:
+			length, partial := escapeLength(data)
+			if partial && len(data) < maxEscapeLength {
+				w.pending = append([]byte{}, data...)
+				data = nil
+				continue
+			}
+			if length == 0 {
+				break // not an escape sequence, so it's text
+			}
+			sequence := data[:length]
+			data = data[length:]
+			if isSGR(string(sequence)) {
+				w.state.apply(string(sequence))
+				if w.reopen {
+					continue // the line will start with the rendition as a whole
+				}
+			}
+			output = append(output, sequence...)
+			continue
+		case '\n':
+			if w.state.active() && !w.reopen {
+				output = append(output, Clear...)
+			}
+			output = append(output, '\n')
+			w.reopen = true
+			data = data[1:]
+			continue
+		}
+
+		if w.reopen {
+			output = append(output, w.state.sequence()...)
+			w.reopen = false
+		}
+		end := bytes.IndexAny(data[1:], "\x1b\n") + 1
+		if end == 0 {
+			end = len(data)
+		}
+		output = append(output, data[:end]...)
+		data = data[end:]
+	}
+
+	if len(output) > 0 {
+		if _, err := w.writer.Write(output); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush forwards an incomplete escape sequence held back from the last write as is.
+func (w *LineColorWriter) Flush() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	pending := w.pending
+	w.pending = nil
+	_, err := w.writer.Write(pending)
+	return err
+}
@@ -0,0 +1,164 @@
+// Copyright 2024, Offchain Labs, Inc.
+// For license information, see https://github.com/nitro/blob/master/LICENSE
+
+package colors
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStripWriter(t *testing.T) {
+	colored := "\033[38;5;48;1mmint\033[0;0m \x1b]8;;https://arbiscan.io\x1b\\link\x1b]8;;\x07 \033[31;1mred\033[0;0m\n"
+
+	var whole bytes.Buffer
+	if _, err := NewStripWriter(&whole).Write([]byte(colored)); err != nil {
+		t.Fatal(err)
+	}
+	if whole.String() != "mint link red\n" {
+		t.Fatalf("unexpected stripped text %q", whole.String())
+	}
+
+	var split bytes.Buffer
+	writer := NewStripWriter(&split)
+	for i := 0; i < len(colored); i++ {
+		n, err := writer.Write([]byte{colored[i]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 {
+			t.Fatalf("write reported %v bytes", n)
+		}
+	}
+	if split.String() != whole.String() {
+		t.Fatalf("writing one byte at a time produced %q", split.String())
+	}
+}
+
+func TestStripWriterMalformed(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewStripWriter(&output)
+	for _, chunk := range []string{"a\x1b", "(Bb\x1b[", "31mc\x1b"} {
+		if _, err := writer.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if output.String() != "a\x1b(Bbc" {
+		t.Fatalf("unexpected output %q", output.String())
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "a\x1b(Bbc\x1b" {
+		t.Fatalf("flushing left %q", output.String())
+	}
+}
+
+// shortWriter accepts a limited number of bytes, then fails.
+type shortWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	n := min(len(p), w.limit-w.Len())
+	w.Buffer.Write(p[:n])
+	if n < len(p) {
+		return n, errors.New("short write")
+	}
+	return n, nil
+}
+
+func TestStripWriterShort(t *testing.T) {
+	cases := []struct {
+		limit    int
+		consumed int
+	}{
+		{0, 5},  // only the leading escape sequence
+		{2, 7},  // "ab"
+		{3, 12}, // "abc", and the escape sequence after it
+		{4, 13}, // "abcd"
+	}
+	for _, test := range cases {
+		output := &shortWriter{limit: test.limit}
+		writer := NewStripWriter(output)
+		n, err := writer.Write([]byte("\033[31mabc\033[0mdef\033["))
+		if err == nil || n != test.consumed {
+			t.Errorf("writing %v bytes reported %v consumed, %v", test.limit, n, err)
+		}
+	}
+
+	output := &shortWriter{limit: 1}
+	writer := NewStripWriter(output)
+	if _, err := writer.Write([]byte("\033[3")); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := writer.Write([]byte("1mab")); err == nil || n != 3 {
+		t.Errorf("a write completing a held sequence reported %v consumed, %v", n, err)
+	}
+}
+
+func TestLineColorWriter(t *testing.T) {
+	input := Red.Wrap("first\nsecond\n\nfourth") + "\n" + string(Underline) + "fifth " + string(Blue) + "sixth\n" +
+		string(Clear) + string(Mint) + "\n" + "seventh" + string(Clear) + "\n"
+	redLine := "\033[1;31m" // red as set again from the state, which lists attributes first
+	expected := []string{
+		string(Red) + "first" + string(Clear),
+		redLine + "second" + string(Clear),
+		"",
+		redLine + "fourth" + string(Clear),
+		string(Underline) + "fifth " + string(Blue) + "sixth" + string(Clear),
+		"",
+		"\033[1;38;5;48m" + "seventh" + string(Clear),
+		"",
+	}
+
+	// the result shouldn't depend on how the input is split across writes
+	for _, size := range []int{len(input), 1, 4} {
+		var output bytes.Buffer
+		writer := NewLineColorWriter(&output)
+		for i := 0; i < len(input); i += size {
+			if _, err := writer.Write([]byte(input[i:min(i+size, len(input))])); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		lines := strings.Split(output.String(), "\n")
+		if len(lines) != len(expected) {
+			t.Fatalf("writes of %v bytes gave %v lines: %q", size, len(lines), output.String())
+		}
+		for i, line := range lines {
+			if line != expected[i] {
+				t.Errorf("writes of %v bytes gave line %v as %q, expected %q", size, i, line, expected[i])
+			}
+			if !Balanced(line) {
+				t.Errorf("line %q leaves colors active", line)
+			}
+		}
+		if StripColor(output.String()) != StripColor(input) {
+			t.Errorf("text changed: %q", StripColor(output.String()))
+		}
+	}
+}
+
+func TestLineColorWriterFlush(t *testing.T) {
+	var output bytes.Buffer
+	writer := NewLineColorWriter(&output)
+	if _, err := writer.Write([]byte("a\x1b")); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "a" {
+		t.Fatalf("the partial sequence wasn't held back: %q", output.String())
+	}
+	if err := writer.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if output.String() != "a\x1b" {
+		t.Fatalf("flushing left %q", output.String())
+	}
+	if err := writer.Flush(); err != nil || output.String() != "a\x1b" {
+		t.Fatalf("flushing again gave %q, %v", output.String(), err)
+	}
+}
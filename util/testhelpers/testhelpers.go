@@ -26,13 +26,13 @@ func RequireImpl(t *testing.T, err error, printables ...interface{}) {
 	t.Helper()
 	if err != nil {
 		t.Log(string(debug.Stack()))
-		t.Fatal(colors.Red, printables, err, colors.Clear)
+		t.Fatal(colors.Red.Sprintln(printables, err))
 	}
 }
 
 func FailImpl(t *testing.T, printables ...interface{}) {
 	t.Helper()
-	t.Fatal(colors.Red, printables, colors.Clear)
+	t.Fatal(colors.Red.Sprintln(printables))
 }
 
 func RandomizeSlice(slice []byte) []byte {